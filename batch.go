@@ -0,0 +1,83 @@
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrShortBuffer is returned by DecodeManyInto when dst cannot hold every
+// decoded UUID.
+var ErrShortBuffer = errors.New("destination slice too short")
+
+// EncodeAll encodes every v7 in uuids in place under key. Unlike the
+// functions that return a new slice it does not allocate, which suits bulk
+// exporters and migrations that own their buffers.
+func EncodeAll(uuids []UUID, key Key) {
+	for i := range uuids {
+		uuids[i] = Encode(uuids[i], key)
+	}
+}
+
+// DecodeAll decodes every facade in facades in place under key.
+func DecodeAll(facades []UUID, key Key) {
+	for i := range facades {
+		facades[i] = Decode(facades[i], key)
+	}
+}
+
+// ReEncodeMany applies ReEncode to each facade, returning the rotated facades
+// in a new slice. The intermediate v7s are never returned.
+func ReEncodeMany(facades []UUID, oldKey, newKey Key) []UUID {
+	out := make([]UUID, len(facades))
+	for i, f := range facades {
+		out[i] = ReEncode(f, oldKey, newKey)
+	}
+	return out
+}
+
+// DecodeMany decodes each facade under key, returning the v7s in a new slice.
+func DecodeMany(src []UUID, key Key) []UUID {
+	out := make([]UUID, len(src))
+	_ = DecodeManyInto(out, src, key)
+	return out
+}
+
+// DecodeManyInto decodes each facade in src under key into the matching index
+// of dst, so that callers can reuse one buffer across batches. dst must be at
+// least as long as src; otherwise nothing is written and an error wrapping
+// ErrShortBuffer is returned. Elements of dst beyond len(src) are left as is.
+// dst and src may be the same slice.
+func DecodeManyInto(dst, src []UUID, key Key) error {
+	if len(dst) < len(src) {
+		return fmt.Errorf("%w: have %d, need %d", ErrShortBuffer, len(dst), len(src))
+	}
+	for i, f := range src {
+		dst[i] = Decode(f, key)
+	}
+	return nil
+}
+
+// EncodePartitioned encodes each v7 under the key that keyFor returns for it,
+// returning the facades in a new slice, for schemes where each partition or
+// shard has its own key. keyFor sees the v7, not the facade, so a decoder
+// must be able to pick the same key from the facade alone: partition on bits
+// that Encode preserves, such as Shard or KeyID, rather than the timestamp.
+func EncodePartitioned(uuids []UUID, keyFor func(UUID) Key) []UUID {
+	out := make([]UUID, len(uuids))
+	for i, u := range uuids {
+		out[i] = Encode(u, keyFor(u))
+	}
+	return out
+}
+
+// EncodeIndexed encodes a UUID field of each record in place. get reads the
+// v7 from a record and set writes the facade back; both receive a pointer to
+// the slice element so that records can be plain structs:
+//
+//	EncodeIndexed(users, func(u *User) UUID { return u.ID },
+//		func(u *User, id UUID) { u.ID = id }, key)
+func EncodeIndexed[T any](items []T, get func(*T) UUID, set func(*T, UUID), key Key) {
+	for i := range items {
+		set(&items[i], Encode(get(&items[i]), key))
+	}
+}
@@ -0,0 +1,157 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodeAll(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facades := randomFacades(t, 32, key)
+	want := DecodeMany(facades, key)
+
+	buf := append([]UUID(nil), facades...)
+	DecodeAll(buf, key)
+	for i := range buf {
+		if buf[i] != want[i] {
+			t.Errorf("DecodeAll element %d = %s, want %s", i, buf[i], want[i])
+		}
+	}
+	EncodeAll(buf, key)
+	for i := range buf {
+		if buf[i] != facades[i] {
+			t.Errorf("EncodeAll element %d = %s, want %s", i, buf[i], facades[i])
+		}
+	}
+
+	if n := testing.AllocsPerRun(10, func() { EncodeAll(buf, key) }); n != 0 {
+		t.Errorf("EncodeAll allocates %v times", n)
+	}
+	EncodeAll(nil, key)
+}
+
+func TestReEncodeMany(t *testing.T) {
+	oldKey := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	newKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+
+	facades := randomFacades(t, 32, oldKey)
+	rotated := ReEncodeMany(facades, oldKey, newKey)
+
+	if len(rotated) != len(facades) {
+		t.Fatalf("got %d facades, want %d", len(rotated), len(facades))
+	}
+	for i := range facades {
+		if Decode(rotated[i], newKey) != Decode(facades[i], oldKey) {
+			t.Errorf("element %d decodes to a different v7 after rotation", i)
+		}
+	}
+}
+
+func TestDecodeManyInto(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facades := randomFacades(t, 16, key)
+
+	want := DecodeMany(facades, key)
+	for i, f := range facades {
+		if want[i] != Decode(f, key) {
+			t.Errorf("DecodeMany element %d mismatch", i)
+		}
+	}
+
+	// A longer buffer is reused across batches; the tail is untouched.
+	sentinel := UUID{0xff}
+	dst := make([]UUID, len(facades)+1)
+	dst[len(facades)] = sentinel
+	for range 2 {
+		if err := DecodeManyInto(dst, facades, key); err != nil {
+			t.Fatalf("DecodeManyInto failed: %v", err)
+		}
+		for i := range facades {
+			if dst[i] != want[i] {
+				t.Errorf("element %d = %s, want %s", i, dst[i], want[i])
+			}
+		}
+		if dst[len(facades)] != sentinel {
+			t.Error("DecodeManyInto wrote past len(src)")
+		}
+	}
+
+	// In place.
+	inPlace := append([]UUID(nil), facades...)
+	if err := DecodeManyInto(inPlace, inPlace, key); err != nil {
+		t.Fatalf("in-place DecodeManyInto failed: %v", err)
+	}
+	for i := range inPlace {
+		if inPlace[i] != want[i] {
+			t.Errorf("in-place element %d mismatch", i)
+		}
+	}
+
+	short := make([]UUID, len(facades)-1)
+	if err := DecodeManyInto(short, facades, key); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("short dst error = %v, want ErrShortBuffer", err)
+	}
+	for i, u := range short {
+		if u != (UUID{}) {
+			t.Errorf("short dst element %d written", i)
+		}
+	}
+}
+
+func TestEncodePartitioned(t *testing.T) {
+	keys := [2]Key{
+		{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210},
+		{K0: 0x1111111111111111, K1: 0x2222222222222222},
+	}
+	// The low bit of byte 15 survives encoding, so it selects the key for
+	// facades as well as v7s.
+	keyFor := func(u UUID) Key { return keys[u[15]&1] }
+
+	var v7s []UUID
+	for i := range uint64(16) {
+		v7s = append(v7s, NewV7FromParts(0x018f2d9f9a2a+i, 0x0def, 0x0c3f7b1a2c4d5e00+i))
+	}
+
+	facades := EncodePartitioned(v7s, keyFor)
+	if len(facades) != len(v7s) {
+		t.Fatalf("got %d facades, want %d", len(facades), len(v7s))
+	}
+	for i, f := range facades {
+		k := keys[v7s[i][15]&1]
+		if f != Encode(v7s[i], k) {
+			t.Errorf("element %d not encoded under its partition key", i)
+		}
+		if Decode(f, keyFor(f)) != v7s[i] {
+			t.Errorf("element %d does not decode under the key chosen from the facade", i)
+		}
+	}
+}
+
+func TestEncodeIndexed(t *testing.T) {
+	type record struct {
+		Name string
+		ID   UUID
+	}
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	records := []record{
+		{"a", NewV7FromParts(1, 0x001, 0x001)},
+		{"b", NewV7FromParts(2, 0x002, 0x002)},
+		{"c", NewV7FromParts(3, 0x003, 0x003)},
+	}
+	originals := make([]UUID, len(records))
+	for i, r := range records {
+		originals[i] = r.ID
+	}
+
+	EncodeIndexed(records,
+		func(r *record) UUID { return r.ID },
+		func(r *record, id UUID) { r.ID = id },
+		key)
+
+	for i, r := range records {
+		if r.ID != Encode(originals[i], key) {
+			t.Errorf("record %q: ID = %s, want %s", r.Name, r.ID, Encode(originals[i], key))
+		}
+	}
+}
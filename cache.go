@@ -0,0 +1,66 @@
+package uuid47
+
+import (
+	"container/list"
+	"sync"
+)
+
+// EncoderCache memoizes Encode results for a single key, evicting the least
+// recently used entries once it holds size facades. Encode is deterministic
+// per key, so a cached facade is always identical to a fresh one.
+//
+// An EncoderCache is safe for concurrent use. It only pays off for small sets
+// of hot IDs; Encode itself is cheap enough that a cold cache is slower.
+type EncoderCache struct {
+	key  Key
+	size int
+
+	mu    sync.Mutex
+	order *list.List // front is most recently used; values are cacheEntry
+	items map[UUID]*list.Element
+}
+
+type cacheEntry struct {
+	v7, facade UUID
+}
+
+// NewEncoderCache returns an EncoderCache for key holding at most size
+// facades. It panics if size <= 0.
+func NewEncoderCache(key Key, size int) *EncoderCache {
+	if size <= 0 {
+		panic("uuid47: invalid cache size")
+	}
+	return &EncoderCache{
+		key:   key,
+		size:  size,
+		order: list.New(),
+		items: make(map[UUID]*list.Element, size),
+	}
+}
+
+// Encode returns the facade for uuid, computing and caching it on a miss.
+func (c *EncoderCache) Encode(uuid UUID) UUID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[uuid]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(cacheEntry).facade
+	}
+
+	facade := Encode(uuid, c.key)
+	c.items[uuid] = c.order.PushFront(cacheEntry{v7: uuid, facade: facade})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(cacheEntry).v7)
+	}
+	return facade
+}
+
+// Len returns the number of cached facades.
+func (c *EncoderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
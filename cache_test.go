@@ -0,0 +1,59 @@
+package uuid47
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEncoderCache(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	c := NewEncoderCache(key, 2)
+
+	a := NewV7FromParts(1, 0x001, 0x001)
+	b := NewV7FromParts(2, 0x002, 0x002)
+	d := NewV7FromParts(3, 0x003, 0x003)
+
+	if got := c.Encode(a); got != Encode(a, key) {
+		t.Errorf("miss: got %s, want %s", got, Encode(a, key))
+	}
+	if got := c.Encode(a); got != Encode(a, key) {
+		t.Errorf("hit: got %s, want %s", got, Encode(a, key))
+	}
+
+	c.Encode(b)
+	c.Encode(a) // a is now most recently used
+	c.Encode(d) // evicts b
+
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+	if _, ok := c.items[b]; ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	for _, u := range []UUID{a, d} {
+		if _, ok := c.items[u]; !ok {
+			t.Errorf("entry %s unexpectedly evicted", u)
+		}
+	}
+	if got := c.Encode(b); got != Encode(b, key) {
+		t.Errorf("after eviction: got %s, want %s", got, Encode(b, key))
+	}
+}
+
+func TestEncoderCacheConcurrent(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	c := NewEncoderCache(key, 4)
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Go(func() {
+			for i := range uint64(100) {
+				u := NewV7FromParts(i%8, uint16(g), i) //nolint:gosec // G115: Safe conversion in test with g < 8
+				if got := c.Encode(u); got != Encode(u, key) {
+					t.Errorf("concurrent Encode mismatch for %s", u)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
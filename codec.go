@@ -0,0 +1,213 @@
+package uuid47
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dchest/siphash"
+)
+
+// Errors returned by invalid Codec options.
+var (
+	ErrInvalidFacadeVersion = errors.New("facade version must be 4 or 8")
+	ErrInvalidMaskWidth     = errors.New("mask width must be between 1 and 48 bits")
+	ErrNilPRF               = errors.New("PRF must not be nil")
+)
+
+// PRF is a keyed pseudorandom function used to derive the timestamp mask from
+// the 10-byte message holding a v7's 74 random bits. Only the low 48 bits of
+// the result are used.
+type PRF func(key Key, msg []byte) uint64
+
+// sipHashPRF is the default PRF, SipHash-2-4 as used by Encode.
+func sipHashPRF(key Key, msg []byte) uint64 {
+	return siphash.Hash(key.K0, key.K1, msg)
+}
+
+// Codec bundles a Key with the operations that use it, so that a service can
+// construct one configured value at startup and inject it instead of passing
+// the raw key to every call. A Codec is immutable once built and safe for
+// concurrent use.
+//
+// With no options a Codec produces exactly the facades of Encode, compatible
+// with the C reference implementation. WithFacadeVersion, WithMaskWidth, and
+// WithPRF each change the facade format; every party must then use a Codec
+// configured the same way.
+type Codec struct {
+	key     Key
+	version byte
+	mask    uint64
+	prf     PRF
+	strict  bool
+	window  Window
+}
+
+// Option configures a Codec in NewCodec. An Option returns an error if its
+// setting is invalid, which NewCodec passes back to the caller.
+type Option func(*Codec) error
+
+// WithFacadeVersion sets the version stamped on facades: 4 (the default), or
+// 8, the RFC 9562 version for custom layouts, which honestly signals that the
+// ID is not random without revealing anything else. Other values return
+// ErrInvalidFacadeVersion.
+func WithFacadeVersion(version byte) Option {
+	return func(c *Codec) error {
+		if version != 4 && version != 8 {
+			return ErrInvalidFacadeVersion
+		}
+		c.version = version
+		return nil
+	}
+}
+
+// WithMaskWidth masks only the lowest bits bits of the 48-bit timestamp and
+// leaves the rest in the clear. Those visible high bits keep facades
+// roughly in creation order at a granularity of 2^bits ms, and reveal the
+// creation time to the same precision: 32 bits, for example, exposes it to
+// within about 50 days. Values outside 1 to 48 (the default) return
+// ErrInvalidMaskWidth.
+func WithMaskWidth(bits int) Option {
+	return func(c *Codec) error {
+		if bits < 1 || bits > 48 {
+			return ErrInvalidMaskWidth
+		}
+		c.mask = 1<<bits - 1
+		return nil
+	}
+}
+
+// WithPRF replaces SipHash-2-4 with prf for deriving the timestamp mask, for
+// deployments standardized on another keyed function. A nil prf returns
+// ErrNilPRF.
+func WithPRF(prf PRF) Option {
+	return func(c *Codec) error {
+		if prf == nil {
+			return ErrNilPRF
+		}
+		c.prf = prf
+		return nil
+	}
+}
+
+// WithStrictDecode makes DecodeString validate its input as DecodeStrict
+// does, using w as the plausible window, so a facade encoded under another
+// key is reported as an error rather than decoded to nonsense. It also sets
+// the window used by the DecodeStrict method. Decode itself cannot report
+// errors and is never checked.
+func WithStrictDecode(w Window) Option {
+	return func(c *Codec) error {
+		c.strict = true
+		c.window = w
+		return nil
+	}
+}
+
+// NewCodec returns a Codec for key, applying opts in order. It returns the
+// first error reported by an option.
+func NewCodec(key Key, opts ...Option) (*Codec, error) {
+	c := &Codec{
+		key:     key,
+		version: 4,
+		mask:    0x0000FFFFFFFFFFFF,
+		prf:     sipHashPRF,
+		window:  DefaultWindow,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Encode converts a UUIDv7 to its facade under the codec's configuration.
+// With default options this is Encode.
+func (c *Codec) Encode(uuid UUID) UUID {
+	out := uuid
+	wr48be(out[:6], rd48be(uuid[:6])^c.mask48(uuid))
+	setVersion(&out, c.version)
+	setVariantRFC4122(&out)
+	return out
+}
+
+// EncodeStrict is like Encode but rejects input that is not an RFC 4122
+// UUIDv7, as EncodeStrict.
+func (c *Codec) EncodeStrict(uuid UUID) (UUID, error) {
+	if err := checkV7(uuid); err != nil {
+		return UUID{}, err
+	}
+	return c.Encode(uuid), nil
+}
+
+// Decode recovers the UUIDv7 from a facade under the codec's configuration.
+// With default options this is Decode.
+func (c *Codec) Decode(facade UUID) UUID {
+	out := facade
+	wr48be(out[:6], rd48be(facade[:6])^c.mask48(facade))
+	setVersion(&out, 7)
+	setVariantRFC4122(&out)
+	return out
+}
+
+// DecodeStrict is like Decode but validates the result as the package-level
+// DecodeStrict does, against the window set by WithStrictDecode (DefaultWindow
+// if none) and the current time. The facade must carry the codec's facade
+// version.
+func (c *Codec) DecodeStrict(facade UUID) (UUID, error) {
+	if facade[6]>>4 != c.version || !isRFCVariant(facade) {
+		return UUID{}, ErrNotFacade
+	}
+	u := c.Decode(facade)
+	if err := c.window.check(u, time.Now()); err != nil {
+		return UUID{}, err
+	}
+	return u, nil
+}
+
+// EncodeAll encodes every v7 in uuids in place, as EncodeAll.
+func (c *Codec) EncodeAll(uuids []UUID) {
+	for i := range uuids {
+		uuids[i] = c.Encode(uuids[i])
+	}
+}
+
+// DecodeAll decodes every facade in facades in place, as DecodeAll.
+func (c *Codec) DecodeAll(facades []UUID) {
+	for i := range facades {
+		facades[i] = c.Decode(facades[i])
+	}
+}
+
+// EncodeString parses s, encodes it, and returns the facade in canonical form.
+// Parse errors are returned unchanged.
+func (c *Codec) EncodeString(s string) (string, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return c.Encode(u).String(), nil
+}
+
+// DecodeString parses s, decodes it, and returns the v7 in canonical form.
+// Parse errors are returned unchanged, as are validation errors when the
+// codec was built with WithStrictDecode.
+func (c *Codec) DecodeString(s string) (string, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if !c.strict {
+		return c.Decode(u).String(), nil
+	}
+	u, err = c.DecodeStrict(u)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// mask48 returns the configured mask for the random bits of u.
+func (c *Codec) mask48(u UUID) uint64 {
+	sipMsg := buildSipInputFromV7(u)
+	return c.prf(c.key, sipMsg[:]) & c.mask
+}
@@ -0,0 +1,238 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCodec(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	c, err := NewCodec(key)
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	facade := c.Encode(v7)
+	if facade != Encode(v7, key) {
+		t.Errorf("Codec.Encode = %s, want %s", facade, Encode(v7, key))
+	}
+	if back := c.Decode(facade); back != v7 {
+		t.Errorf("Codec.Decode = %s, want %s", back, v7)
+	}
+
+	s, err := c.EncodeString("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if err != nil {
+		t.Fatalf("EncodeString failed: %v", err)
+	}
+	if s != "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f" {
+		t.Errorf("EncodeString = %s, want 2463c780-7fca-4def-8c3f-7b1a2c4d5e6f", s)
+	}
+	back, err := c.DecodeString(s)
+	if err != nil {
+		t.Fatalf("DecodeString failed: %v", err)
+	}
+	if back != "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f" {
+		t.Errorf("DecodeString = %s, want 018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", back)
+	}
+
+	if _, err := c.EncodeString("not-a-uuid"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("EncodeString error = %v, want ErrInvalidUUID", err)
+	}
+	if _, err := c.DecodeString("not-a-uuid"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("DecodeString error = %v, want ErrInvalidUUID", err)
+	}
+}
+
+func TestCodecEncodeAll(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	c, err := NewCodec(key, WithFacadeVersion(8))
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+
+	var v7s []UUID
+	for i := range uint64(8) {
+		v7s = append(v7s, NewV7FromParts(0x018f2d9f9a2a+i, 0x0def, 0x0c3f7b1a2c4d5e6f+i))
+	}
+	buf := append([]UUID(nil), v7s...)
+
+	c.EncodeAll(buf)
+	for i := range buf {
+		if buf[i] != c.Encode(v7s[i]) {
+			t.Errorf("EncodeAll element %d = %s, want %s", i, buf[i], c.Encode(v7s[i]))
+		}
+	}
+	c.DecodeAll(buf)
+	for i := range buf {
+		if buf[i] != v7s[i] {
+			t.Errorf("DecodeAll element %d = %s, want %s", i, buf[i], v7s[i])
+		}
+	}
+}
+
+func TestCodecEncodeStrict(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	c, err := NewCodec(key)
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	facade, err := c.EncodeStrict(v7)
+	if err != nil {
+		t.Fatalf("EncodeStrict failed: %v", err)
+	}
+	if facade != Encode(v7, key) {
+		t.Errorf("EncodeStrict = %s, want %s", facade, Encode(v7, key))
+	}
+	if _, err := c.EncodeStrict(facade); !errors.Is(err, ErrNotV7) {
+		t.Errorf("EncodeStrict(facade) error = %v, want ErrNotV7", err)
+	}
+}
+
+func TestNewCodecOptionError(t *testing.T) {
+	errOpt := errors.New("bad option")
+	var applied []int
+	opt := func(n int, err error) Option {
+		return func(*Codec) error {
+			applied = append(applied, n)
+			return err
+		}
+	}
+
+	c, err := NewCodec(Key{}, opt(1, nil), opt(2, errOpt), opt(3, nil))
+	if !errors.Is(err, errOpt) {
+		t.Errorf("NewCodec error = %v, want %v", err, errOpt)
+	}
+	if c != nil {
+		t.Error("NewCodec returned a codec alongside an error")
+	}
+	if len(applied) != 2 {
+		t.Errorf("applied options %v, want [1 2]", applied)
+	}
+}
+
+func TestCodecOptions(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	constPRF := func(Key, []byte) uint64 { return 0xFFFFFFFFFFFFFFFF }
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(t *testing.T, facade UUID)
+	}{
+		{"defaults match Encode", nil, func(t *testing.T, f UUID) {
+			if f != Encode(v7, key) {
+				t.Errorf("facade = %s, want %s", f, Encode(v7, key))
+			}
+		}},
+		{"version 8", []Option{WithFacadeVersion(8)}, func(t *testing.T, f UUID) {
+			want := Encode(v7, key)
+			setVersion(&want, 8)
+			if f != want {
+				t.Errorf("facade = %s, want %s", f, want)
+			}
+		}},
+		{"mask width 16", []Option{WithMaskWidth(16)}, func(t *testing.T, f UUID) {
+			if [4]byte(f[:4]) != [4]byte(v7[:4]) {
+				t.Errorf("high 32 timestamp bits changed: %s vs %s", f, v7)
+			}
+			if [2]byte(f[4:6]) == [2]byte(v7[4:6]) {
+				t.Errorf("low 16 timestamp bits unmasked: %s", f)
+			}
+		}},
+		{"custom PRF", []Option{WithPRF(constPRF)}, func(t *testing.T, f UUID) {
+			if got, want := rd48be(f[:6]), rd48be(v7[:6])^0xFFFFFFFFFFFF; got != want {
+				t.Errorf("timestamp field = %012x, want %012x", got, want)
+			}
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewCodec(key, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewCodec failed: %v", err)
+			}
+			f := c.Encode(v7)
+			tc.check(t, f)
+			if back := c.Decode(f); back != v7 {
+				t.Errorf("roundtrip = %s, want %s", back, v7)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name    string
+		opt     Option
+		wantErr error
+	}{
+		{"version 5", WithFacadeVersion(5), ErrInvalidFacadeVersion},
+		{"version 7", WithFacadeVersion(7), ErrInvalidFacadeVersion},
+		{"mask width 0", WithMaskWidth(0), ErrInvalidMaskWidth},
+		{"mask width 49", WithMaskWidth(49), ErrInvalidMaskWidth},
+		{"nil PRF", WithPRF(nil), ErrNilPRF},
+	}
+
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewCodec(key, tc.opt); !errors.Is(err, tc.wantErr) {
+				t.Errorf("NewCodec error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCodecStrictDecode(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	wrongKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+	v7 := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	facade := "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f"
+	w := Window{NotBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Tolerance: time.Minute}
+
+	strict, err := NewCodec(key, WithStrictDecode(w))
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+	if got, err := strict.DecodeString(facade); err != nil || got != v7 {
+		t.Errorf("DecodeString = %s, %v; want %s", got, err, v7)
+	}
+	if _, err := strict.DecodeString(v7); !errors.Is(err, ErrNotFacade) {
+		t.Errorf("DecodeString(v7) error = %v, want ErrNotFacade", err)
+	}
+
+	wrong, err := NewCodec(wrongKey, WithStrictDecode(w))
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+	if _, err := wrong.DecodeString(facade); !errors.Is(err, ErrImplausibleTimestamp) {
+		t.Errorf("wrong-key DecodeString error = %v, want ErrImplausibleTimestamp", err)
+	}
+
+	// Without the option DecodeString is unchecked, but DecodeStrict is not.
+	lax, err := NewCodec(wrongKey)
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+	if _, err := lax.DecodeString(facade); err != nil {
+		t.Errorf("unchecked DecodeString failed: %v", err)
+	}
+	if _, err := lax.DecodeStrict(mustParse(t, facade)); !errors.Is(err, ErrImplausibleTimestamp) {
+		t.Errorf("DecodeStrict error = %v, want ErrImplausibleTimestamp", err)
+	}
+
+	// A version 8 codec expects version 8 facades.
+	v8, err := NewCodec(key, WithFacadeVersion(8))
+	if err != nil {
+		t.Fatalf("NewCodec failed: %v", err)
+	}
+	if _, err := v8.DecodeStrict(mustParse(t, facade)); !errors.Is(err, ErrNotFacade) {
+		t.Errorf("v8 DecodeStrict(v4) error = %v, want ErrNotFacade", err)
+	}
+	if got, err := v8.DecodeStrict(v8.Encode(mustParse(t, v7))); err != nil || got.String() != v7 {
+		t.Errorf("v8 DecodeStrict = %s, %v; want %s", got, err, v7)
+	}
+}
@@ -0,0 +1,46 @@
+package uuid47
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNoDefaultKey is returned by EncodeDefault and DecodeDefault when
+// SetDefaultKey has not been called.
+var ErrNoDefaultKey = errors.New("no default key set")
+
+// defaultCodec holds the codec installed by SetDefaultKey, or nil.
+var defaultCodec atomic.Pointer[Codec]
+
+// SetDefaultKey installs key as the process-wide default used by
+// EncodeDefault and DecodeDefault, for applications with a single key where
+// marshaling hooks and middleware cannot easily be handed one. It is safe to
+// call concurrently with the default functions, for example to rotate keys;
+// each call sees either the old or the new key in full.
+//
+// Prefer passing a Codec explicitly where that is practical: process-wide
+// state makes tests and multi-tenant use harder.
+func SetDefaultKey(key Key) {
+	c, _ := NewCodec(key) // cannot fail without options
+	defaultCodec.Store(c)
+}
+
+// EncodeDefault encodes uuid under the key set by SetDefaultKey. It returns
+// ErrNoDefaultKey if no default has been set.
+func EncodeDefault(uuid UUID) (UUID, error) {
+	c := defaultCodec.Load()
+	if c == nil {
+		return UUID{}, ErrNoDefaultKey
+	}
+	return c.Encode(uuid), nil
+}
+
+// DecodeDefault decodes facade under the key set by SetDefaultKey. It returns
+// ErrNoDefaultKey if no default has been set.
+func DecodeDefault(facade UUID) (UUID, error) {
+	c := defaultCodec.Load()
+	if c == nil {
+		return UUID{}, ErrNoDefaultKey
+	}
+	return c.Decode(facade), nil
+}
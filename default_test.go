@@ -0,0 +1,60 @@
+package uuid47
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestDefaultKey(t *testing.T) {
+	t.Cleanup(func() { defaultCodec.Store(nil) })
+	defaultCodec.Store(nil)
+
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if _, err := EncodeDefault(v7); !errors.Is(err, ErrNoDefaultKey) {
+		t.Errorf("EncodeDefault without key error = %v, want ErrNoDefaultKey", err)
+	}
+	if _, err := DecodeDefault(v7); !errors.Is(err, ErrNoDefaultKey) {
+		t.Errorf("DecodeDefault without key error = %v, want ErrNoDefaultKey", err)
+	}
+
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	SetDefaultKey(key)
+
+	facade, err := EncodeDefault(v7)
+	if err != nil {
+		t.Fatalf("EncodeDefault failed: %v", err)
+	}
+	if facade != Encode(v7, key) {
+		t.Errorf("EncodeDefault = %s, want %s", facade, Encode(v7, key))
+	}
+	back, err := DecodeDefault(facade)
+	if err != nil {
+		t.Fatalf("DecodeDefault failed: %v", err)
+	}
+	if back != v7 {
+		t.Errorf("DecodeDefault = %s, want %s", back, v7)
+	}
+
+	// Rotating concurrently with use never mixes keys.
+	other := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+	valid := map[UUID]bool{Encode(v7, key): true, Encode(v7, other): true}
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 1000 {
+			SetDefaultKey([]Key{key, other}[i%2])
+		}
+	})
+	for range 4 {
+		wg.Go(func() {
+			for range 1000 {
+				f, err := EncodeDefault(v7)
+				if err != nil || !valid[f] {
+					t.Errorf("EncodeDefault during rotation = %s, %v", f, err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,135 @@
+package uuid47
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"math/bits"
+	"strings"
+)
+
+// base32HexAlphabet is the RFC 4648 extended hex alphabet. It is in ASCII
+// order, so encodings of equal length sort exactly like the bytes they encode.
+const base32HexAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUV"
+
+// base32Sortable is base32 with the extended hex alphabet and no padding.
+var base32Sortable = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// Base32Sortable returns the UUID as 26 characters of RFC 4648 base32 using
+// the extended hex alphabet (0-9A-V), without padding.
+//
+// Unlike the standard base32 alphabet, where '2'-'7' sort before 'A', or
+// base58 and base64, this encoding preserves byte order: for v7s, comparing
+// the strings orders them by creation time, which makes it suitable for keys
+// in ordered key-value stores. The output is uppercase and
+// ParseBase32Sortable accepts either case.
+func (u UUID) Base32Sortable() string {
+	return base32Sortable.EncodeToString(u[:])
+}
+
+// ParseBase32Sortable parses the 26-character form produced by
+// Base32Sortable, in either case.
+func ParseBase32Sortable(s string) (UUID, error) {
+	var u UUID
+	if len(s) != base32Sortable.EncodedLen(16) {
+		return u, ErrInvalidUUID
+	}
+	upper := strings.ToUpper(s)
+	// 26 digits carry 130 bits; the final digit's 2 spare bits must be zero so
+	// that every UUID has exactly one encoding.
+	if strings.IndexByte(base32HexAlphabet, upper[len(upper)-1])&3 != 0 {
+		return u, ErrInvalidUUID
+	}
+	if _, err := base32Sortable.Decode(u[:], []byte(upper)); err != nil {
+		return UUID{}, ErrInvalidUUID
+	}
+	return u, nil
+}
+
+// base32StdAlphabet is the RFC 4648 standard base32 alphabet. Every character
+// is in the QR code alphanumeric set (0-9, A-Z, space, $%*+-./:).
+const base32StdAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// base32QR is standard base32 without padding.
+var base32QR = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// QRString returns the UUID as 26 characters of uppercase RFC 4648 base32
+// without padding. The output fits QR alphanumeric mode, which packs two
+// characters into 11 bits, so it encodes smaller than the canonical form
+// (whose lowercase hex forces byte mode). It does not sort like the
+// underlying bytes; see Base32Sortable for that.
+func (u UUID) QRString() string {
+	return base32QR.EncodeToString(u[:])
+}
+
+// ParseQRString parses the 26-character form produced by QRString, in either
+// case.
+func ParseQRString(s string) (UUID, error) {
+	var u UUID
+	if len(s) != base32QR.EncodedLen(16) {
+		return u, ErrInvalidUUID
+	}
+	upper := strings.ToUpper(s)
+	// As in ParseBase32Sortable, the final digit's 2 spare bits must be zero.
+	if strings.IndexByte(base32StdAlphabet, upper[len(upper)-1])&3 != 0 {
+		return u, ErrInvalidUUID
+	}
+	if _, err := base32QR.Decode(u[:], []byte(upper)); err != nil {
+		return UUID{}, ErrInvalidUUID
+	}
+	return u, nil
+}
+
+// base62Alphabet is digits, then uppercase, then lowercase letters: ASCII
+// order, like base32HexAlphabet.
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// base62Len is the number of base62 digits needed for 128 bits: 62^21 < 2^128
+// <= 62^22.
+const base62Len = 22
+
+// Base62 returns the UUID as a 22-character base62 (0-9A-Za-z) string, a
+// URL-safe short form with no punctuation.
+//
+// The value is the UUID's 16 bytes read as a big-endian 128-bit integer. The
+// output is always left-padded with '0' to 22 characters, so leading zero
+// bytes survive the round trip and, because the alphabet is in ASCII order,
+// strings sort like the underlying bytes.
+func (u UUID) Base62() string {
+	hi, lo := binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+	var buf [base62Len]byte
+	for i := base62Len - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, 62)
+		lo, rem = bits.Div64(rem, lo, 62)
+		buf[i] = base62Alphabet[rem]
+	}
+	return string(buf[:])
+}
+
+// ParseBase62 parses the 22-character form produced by Base62. It is case
+// sensitive, and rejects any string whose value does not fit in 128 bits.
+func ParseBase62(s string) (UUID, error) {
+	if len(s) != base62Len {
+		return UUID{}, ErrInvalidUUID
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base62Alphabet, s[i])
+		if d < 0 {
+			return UUID{}, ErrInvalidUUID
+		}
+		// (hi, lo) = (hi, lo)*62 + d, failing on carry out of the top word.
+		carry, l := bits.Mul64(lo, 62)
+		overflow, h := bits.Mul64(hi, 62)
+		var c uint64
+		lo, c = bits.Add64(l, uint64(d), 0) //nolint:gosec // G115: d is a digit value in [0, 62)
+		hi, c = bits.Add64(h, carry, c)
+		if overflow != 0 || c != 0 {
+			return UUID{}, ErrInvalidUUID
+		}
+	}
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
@@ -0,0 +1,127 @@
+package uuid47
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBase32Sortable(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	s := u.Base32Sortable()
+
+	if len(s) != 26 {
+		t.Errorf("Base32Sortable length = %d, want 26", len(s))
+	}
+	for _, in := range []string{s, strings.ToLower(s)} {
+		back, err := ParseBase32Sortable(in)
+		if err != nil {
+			t.Fatalf("ParseBase32Sortable(%q) failed: %v", in, err)
+		}
+		if back != u {
+			t.Errorf("roundtrip mismatch: %s != %s", back, u)
+		}
+	}
+
+	for _, bad := range []string{"", s[:25], s + "0", "W" + s[1:], s[:25] + "1"} {
+		if _, err := ParseBase32Sortable(bad); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseBase32Sortable(%q) error = %v, want ErrInvalidUUID", bad, err)
+		}
+	}
+}
+
+func TestBase32SortableOrder(t *testing.T) {
+	// Spread timestamps and random bits so every base32 digit value occurs.
+	var v7s []UUID
+	for i := range uint64(200) {
+		ts := 0x018f2d9f0000 + i*i*7919
+		rb := ((200 - i) * 0x0123456789ABCDEF) & ((1 << 62) - 1)
+		v7s = append(v7s, NewV7FromParts(ts, uint16(0x0FFF-i), rb)) //nolint:gosec // G115: Safe conversion in test with i < 200
+	}
+
+	var encoded []string
+	for _, u := range v7s {
+		encoded = append(encoded, u.Base32Sortable())
+	}
+	if !slices.IsSorted(encoded) {
+		t.Error("Base32Sortable strings do not sort in creation-time order")
+	}
+}
+
+func TestQRString(t *testing.T) {
+	const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+	for _, u := range []UUID{
+		{},
+		mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"),
+		mustParse(t, "ffffffff-ffff-ffff-ffff-ffffffffffff"),
+	} {
+		s := u.QRString()
+		if len(s) != 26 {
+			t.Errorf("QRString(%s) length = %d, want 26", u, len(s))
+		}
+		for _, c := range s {
+			if !strings.ContainsRune(qrAlphanumeric, c) {
+				t.Errorf("QRString(%s) = %q contains %q outside the QR alphanumeric set", u, s, c)
+			}
+		}
+		for _, in := range []string{s, strings.ToLower(s)} {
+			back, err := ParseQRString(in)
+			if err != nil {
+				t.Fatalf("ParseQRString(%q) failed: %v", in, err)
+			}
+			if back != u {
+				t.Errorf("roundtrip mismatch: %s != %s", back, u)
+			}
+		}
+	}
+
+	s := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f").QRString()
+	for _, bad := range []string{"", s[:25], s + "A", "1" + s[1:], s[:25] + "B"} {
+		if _, err := ParseQRString(bad); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseQRString(%q) error = %v, want ErrInvalidUUID", bad, err)
+		}
+	}
+}
+
+func TestBase62(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		want string
+	}{
+		{"zero", UUID{}, "0000000000000000000000"},
+		{"one", UUID{15: 1}, "0000000000000000000001"},
+		{"sixty-two", UUID{15: 62}, "0000000000000000000010"},
+		{"max", UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "7n42DGM5Tflk9n8mt7Fhc7"},
+		{"leading zero bytes", mustParse(t, "0000000f-9a2a-7def-8c3f-7b1a2c4d5e6f"), ""},
+		{"v7", mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"), ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.u.Base62()
+			if len(s) != 22 {
+				t.Errorf("Base62 length = %d, want 22", len(s))
+			}
+			if tc.want != "" && s != tc.want {
+				t.Errorf("Base62 = %q, want %q", s, tc.want)
+			}
+			back, err := ParseBase62(s)
+			if err != nil {
+				t.Fatalf("ParseBase62(%q) failed: %v", s, err)
+			}
+			if back != tc.u {
+				t.Errorf("roundtrip mismatch: %s != %s", back, tc.u)
+			}
+		})
+	}
+
+	// "7n42DGM5Tflk9n8mt7Fhc8" is 2^128 and does not fit.
+	for _, bad := range []string{"", "000000000000000000001", "00000000000000000000000", "000000000000000000000-", "7n42DGM5Tflk9n8mt7Fhc8", "zzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := ParseBase62(bad); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseBase62(%q) error = %v, want ErrInvalidUUID", bad, err)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package uuid47
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+)
+
+// ErrLowEntropyFacade is returned by EncodeStrictV4 when the facade's random
+// bits are degenerate and would be rejected by strict v4 validators.
+var ErrLowEntropyFacade = errors.New("facade random bits have low entropy")
+
+// ErrNotFacade is returned when an input that must be a facade does not carry
+// the facade version (4, unless configured otherwise on a Codec) and the RFC
+// 4122 variant.
+var ErrNotFacade = errors.New("UUID is not a facade")
+
+// CanDetectFacadeWithoutKey always returns false. It exists to document, in
+// code, that a facade cannot be told apart from a genuine random UUIDv4
+// without the key.
+//
+// A facade is a version 4, RFC-variant UUID whose remaining 122 bits are the
+// original random bits plus a timestamp XOR-masked with a keyed PRF output.
+// Without the key that masked timestamp is indistinguishable from random, so
+// every well-formed v4 is an equally plausible facade. Schemes that try to
+// "detect" facades by statistics, ranges, or bit patterns will misclassify
+// real v4s and, worse, may leak information about the key. If a system needs
+// to know which IDs are facades, record that out-of-band or decode with the
+// key (see IsPossibleFacade for the only structural check available).
+func CanDetectFacadeWithoutKey() bool {
+	return false
+}
+
+// IsPossibleFacade reports whether u is structurally a valid facade: version 4
+// with the RFC 4122 variant. It says nothing about whether u was actually
+// produced by Encode; see CanDetectFacadeWithoutKey.
+func IsPossibleFacade(u UUID) bool {
+	return u[6]>>4 == 4 && isRFCVariant(u)
+}
+
+// DetectAndDecode decodes u under key if it looks like one of our facades, for
+// tables that mix legacy random v4s with facades. It returns the v7 and true
+// when u is a v4 with the RFC variant whose decoded timestamp falls in
+// DefaultWindow; otherwise it returns u unchanged and false.
+//
+// The decision is probabilistic in one direction. A real facade under key is
+// always recognised (provided its v7 was created in the window), but a legacy
+// random v4 is taken for a facade whenever its decoded timestamp happens to
+// land in the window (see DecodeStrict for the odds). Where the distinction
+// matters, record it out-of-band instead; see CanDetectFacadeWithoutKey.
+func DetectAndDecode(u UUID, key Key) (UUID, bool) {
+	return DetectAndDecodeAt(u, key, time.Now())
+}
+
+// DetectAndDecodeAt is like DetectAndDecode but checks the window relative to
+// now instead of the current time.
+func DetectAndDecodeAt(u UUID, key Key, now time.Time) (UUID, bool) {
+	v7, err := DecodeStrict(u, key, DefaultWindow, now)
+	if err != nil {
+		return u, false
+	}
+	return v7, true
+}
+
+// AllWellFormedFacades reports whether every element of facades passes
+// IsPossibleFacade, along with the indices of those that do not. It is a cheap
+// pre-send gate against v7s leaking into an outbound batch.
+func AllWellFormedFacades(facades []UUID) (bool, []int) {
+	var bad []int
+	for i, f := range facades {
+		if !IsPossibleFacade(f) {
+			bad = append(bad, i)
+		}
+	}
+	return len(bad) == 0, bad
+}
+
+// IsConsistentFacade reports whether facade looks like the output of Encode
+// under key: re-encoding Decode(facade, key) must reproduce facade exactly,
+// and the decoded timestamp must be plausible under DefaultWindow at the
+// current time, as checked by DecodeStrict.
+//
+// The round trip catches corruption of the version and variant fields. The
+// timestamp check catches most other corruption: the mask is derived from the
+// random bits, so flipping any of them scrambles the decoded timestamp, as
+// does flipping a high timestamp bit. A flipped low timestamp bit only shifts
+// the decoded time slightly and goes undetected, as does a scrambled timestamp
+// that happens to land in the window (see DecodeStrict for the odds). Use
+// FacadeTag or SignedToken when every bit must be covered.
+func IsConsistentFacade(facade UUID, key Key) bool {
+	return IsConsistentFacadeAt(facade, key, time.Now())
+}
+
+// IsConsistentFacadeAt is like IsConsistentFacade but checks the decoded
+// timestamp relative to now instead of the current time.
+func IsConsistentFacadeAt(facade UUID, key Key, now time.Time) bool {
+	u, err := DecodeStrict(facade, key, DefaultWindow, now)
+	return err == nil && Encode(u, key) == facade
+}
+
+// EncodeStrictV4 encodes uuid like Encode, but returns ErrLowEntropyFacade
+// if uuid's 74 random bits are all zeros or all ones, patterns some strict
+// UUIDv4 validators reject. No other pattern is rejected. The version and
+// variant need no check, since Encode always sets version 4 and the RFC 4122
+// variant.
+//
+// Encode copies the random bits of the v7 into the facade unchanged, and they
+// cannot be altered without breaking reversibility, so it is left to the
+// caller to regenerate the ID.
+func EncodeStrictV4(uuid UUID, key Key) (UUID, error) {
+	if lowEntropy(buildSipInputFromV7(uuid)) {
+		return UUID{}, ErrLowEntropyFacade
+	}
+	return Encode(uuid, key), nil
+}
+
+// lowEntropy reports whether the 74 random bits extracted by
+// buildSipInputFromV7 are all zeros or all ones.
+func lowEntropy(msg [10]byte) bool {
+	allOnes := [10]byte{0x0F, 0xFF, 0x3F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	return msg == [10]byte{} || msg == allOnes
+}
+
+// SameSource reports whether facades a and b decode to the same UUIDv7 under
+// key. The decoded values are compared in constant time and never returned,
+// so an edge service can deduplicate incoming facades without exposing the
+// underlying IDs.
+func SameSource(a, b UUID, key Key) bool {
+	da, db := Decode(a, key), Decode(b, key)
+	return subtle.ConstantTimeCompare(da[:], db[:]) == 1
+}
+
+// orderingThreshold is the fraction of adjacent pairs that must be in
+// timestamp order for CheckKeyOrdering to accept a key.
+const orderingThreshold = 0.9
+
+// CheckKeyOrdering is a heuristic for validating a candidate key against a
+// batch of facades known to be in creation order, for example during a
+// migration where the key is uncertain. It decodes every facade under key and
+// reports whether at least 90% of adjacent pairs have non-decreasing
+// timestamps.
+//
+// Under the wrong key the recovered timestamps are effectively random, so
+// each pair is in order with probability about 1/2. The chance of a wrong key
+// passing therefore falls quickly with batch size but is far from zero for
+// small batches: two facades pass half the time. Use batches of at least a
+// few dozen and confirm with a known ID where possible. Batches with fewer
+// than two facades always pass.
+func CheckKeyOrdering(facades []UUID, key Key) bool {
+	if len(facades) < 2 {
+		return true
+	}
+	inOrder := 0
+	first := Decode(facades[0], key)
+	prev := rd48be(first[:6])
+	for _, f := range facades[1:] {
+		u := Decode(f, key)
+		ts := rd48be(u[:6])
+		if ts >= prev {
+			inOrder++
+		}
+		prev = ts
+	}
+	return float64(inOrder) >= orderingThreshold*float64(len(facades)-1)
+}
+
+// auditTimeLayout is RFC 3339 with fixed millisecond precision, matching the
+// resolution of a v7 timestamp.
+const auditTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// AuditLine decodes the facade u under key and returns a single human-readable
+// line of the form
+//
+//	facade=<facade> v7=<decoded> time=<RFC 3339 creation time, UTC>
+//
+// The line reveals the stored v7 and its creation time, so it must only be
+// written to logs that are restricted to key holders.
+func (u UUID) AuditLine(key Key) string {
+	v7 := Decode(u, key)
+	return "facade=" + u.String() + " v7=" + v7.String() + " time=" + unixMilliTime(v7).Format(auditTimeLayout)
+}
@@ -0,0 +1,235 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCanDetectFacadeWithoutKey(t *testing.T) {
+	if CanDetectFacadeWithoutKey() {
+		t.Error("CanDetectFacadeWithoutKey should always be false")
+	}
+}
+
+func TestIsPossibleFacade(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u7, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name  string
+		input UUID
+		want  bool
+	}{
+		{"facade", Encode(u7, key), true},
+		{"v5", NewV5(NamespaceDNS, nil), false},
+		{"v7", u7, false},
+		{"v4 with Microsoft variant", mustParse(t, "2463c780-7fca-4def-cc3f-7b1a2c4d5e6f"), false},
+		{"random v4", mustParse(t, "9b2c1f4e-0d3a-4c8b-a1e2-3f4d5c6b7a89"), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsPossibleFacade(tc.input); got != tc.want {
+				t.Errorf("IsPossibleFacade(%s) = %v, want %v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetectAndDecode(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name   string
+		input  UUID
+		want   UUID
+		wantOK bool
+	}{
+		{"facade", Encode(v7, key), v7, true},
+		{"legacy v4", mustParse(t, "6ba7b810-9dad-41d1-80b4-00c04fd430c8"), mustParse(t, "6ba7b810-9dad-41d1-80b4-00c04fd430c8"), false},
+		{"v7", v7, v7, false},
+		{"facade under another key", Encode(v7, Key{K0: 1, K1: 2}), Encode(v7, Key{K0: 1, K1: 2}), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := DetectAndDecode(tc.input, key)
+			if ok != tc.wantOK || got != tc.want {
+				t.Errorf("DetectAndDecode = %s, %v; want %s, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestDetectAndDecodeAt(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	facade := Encode(v7, key)
+	created := unixMilliTime(v7)
+
+	if got, ok := DetectAndDecodeAt(facade, key, created.Add(time.Hour)); !ok || got != v7 {
+		t.Errorf("DetectAndDecodeAt after creation = %s, %v; want %s, true", got, ok, v7)
+	}
+	if got, ok := DetectAndDecodeAt(facade, key, created.Add(-time.Hour)); ok || got != facade {
+		t.Errorf("DetectAndDecodeAt before creation = %s, %v; want %s, false", got, ok, facade)
+	}
+}
+
+func TestAllWellFormedFacades(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facades := randomFacades(t, 5, key)
+
+	ok, bad := AllWellFormedFacades(facades)
+	if !ok || len(bad) != 0 {
+		t.Errorf("AllWellFormedFacades = %v, %v; want true, []", ok, bad)
+	}
+
+	facades[1] = Decode(facades[1], key) // leaked v7
+	facades[3][8] &= 0x3F                // broken variant
+	ok, bad = AllWellFormedFacades(facades)
+	if ok {
+		t.Error("AllWellFormedFacades = true for a batch with a stray v7")
+	}
+	if len(bad) != 2 || bad[0] != 1 || bad[1] != 3 {
+		t.Errorf("bad indices = %v, want [1 3]", bad)
+	}
+}
+
+func TestIsConsistentFacade(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := Encode(NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f), key)
+
+	if !IsConsistentFacade(facade, key) {
+		t.Error("genuine facade reported inconsistent")
+	}
+
+	badVersion := facade
+	badVersion[6] ^= 0x10
+	if IsConsistentFacade(badVersion, key) {
+		t.Error("facade with corrupted version reported consistent")
+	}
+
+	badVariant := facade
+	badVariant[8] ^= 0x40
+	if IsConsistentFacade(badVariant, key) {
+		t.Error("facade with corrupted variant reported consistent")
+	}
+
+	badRandom := facade
+	badRandom[15] ^= 0x01
+	if IsConsistentFacade(badRandom, key) {
+		t.Error("facade with a flipped random bit reported consistent")
+	}
+
+	badHighTime := facade
+	badHighTime[0] ^= 0x80
+	if IsConsistentFacade(badHighTime, key) {
+		t.Error("facade with a flipped high timestamp bit reported consistent")
+	}
+
+	// A flipped low timestamp bit moves the decoded time by a millisecond,
+	// which no check short of a tag can notice.
+	lowTime := facade
+	lowTime[5] ^= 0x01
+	if !IsConsistentFacade(lowTime, key) {
+		t.Error("facade with a flipped low timestamp bit reported inconsistent")
+	}
+}
+
+func TestIsConsistentFacadeAt(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	facade := Encode(v7, key)
+	created := unixMilliTime(v7)
+
+	if !IsConsistentFacadeAt(facade, key, created.Add(time.Hour)) {
+		t.Error("facade checked after creation reported inconsistent")
+	}
+	if IsConsistentFacadeAt(facade, key, created.Add(-time.Hour)) {
+		t.Error("facade checked an hour before creation reported consistent")
+	}
+}
+
+func TestEncodeStrictV4(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	tests := []struct {
+		name    string
+		input   UUID
+		wantErr bool
+	}{
+		{"normal v7", NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f), false},
+		{"all-zero random bits", NewV7FromParts(0x018f2d9f9a2a, 0, 0), true},
+		{"all-one random bits", NewV7FromParts(0x018f2d9f9a2a, 0x0FFF, (1<<62)-1), true},
+		{"single random bit", NewV7FromParts(0x018f2d9f9a2a, 0, 1), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			facade, err := EncodeStrictV4(tc.input, key)
+			if tc.wantErr {
+				if !errors.Is(err, ErrLowEntropyFacade) {
+					t.Errorf("EncodeStrictV4 error = %v, want ErrLowEntropyFacade", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EncodeStrictV4 failed: %v", err)
+			}
+			if facade != Encode(tc.input, key) {
+				t.Error("EncodeStrictV4 does not match Encode")
+			}
+		})
+	}
+}
+
+func TestSameSource(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	a := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	b := NewV7FromParts(0x018f2d9f9a2b, 0x0def, 0x0c3f7b1a2c4d5e6f)
+
+	if !SameSource(Encode(a, key), Encode(a, key), key) {
+		t.Error("facades of the same v7 reported as different sources")
+	}
+	if SameSource(Encode(a, key), Encode(b, key), key) {
+		t.Error("facades of different v7s reported as the same source")
+	}
+}
+
+func TestCheckKeyOrdering(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	wrongKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+
+	facades := make([]UUID, 64)
+	for i := range uint64(64) {
+		ts := 0x018f2d9f0000 + i*1000
+		ra := uint16((i * 37) & 0x0FFF) //nolint:gosec // G115: Safe conversion in test with i < 64
+		rb := (uint64(0x0123456789ABCDEF) ^ i<<8) & ((1 << 62) - 1)
+		facades[i] = Encode(NewV7FromParts(ts, ra, rb), key)
+	}
+
+	if !CheckKeyOrdering(facades, key) {
+		t.Error("correct key rejected")
+	}
+	if CheckKeyOrdering(facades, wrongKey) {
+		t.Error("wrong key accepted")
+	}
+	if !CheckKeyOrdering(facades[:1], wrongKey) {
+		t.Error("single-element batch should pass vacuously")
+	}
+}
+
+func TestAuditLine(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := mustParse(t, "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f")
+
+	got := facade.AuditLine(key)
+	want := "facade=2463c780-7fca-4def-8c3f-7b1a2c4d5e6f " +
+		"v7=018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f " +
+		"time=2024-04-30T06:09:45.514Z"
+	if got != want {
+		t.Errorf("AuditLine =\n%s\nwant\n%s", got, want)
+	}
+}
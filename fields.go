@@ -0,0 +1,105 @@
+package uuid47
+
+import "encoding/binary"
+
+// Variant is the layout family of a UUID, given by the high bits of byte 8.
+type Variant byte
+
+// The variants defined by RFC 9562 section 4.1.
+const (
+	VariantNCS       Variant = iota // 0xxx, reserved for NCS backward compatibility
+	VariantRFC4122                  // 10xx, the variant of every UUID this package generates
+	VariantMicrosoft                // 110x, reserved for Microsoft backward compatibility
+	VariantFuture                   // 111x, reserved for future definition
+)
+
+// String returns the name of the variant.
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC4122:
+		return "RFC 4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	}
+	return "Invalid"
+}
+
+// Version returns the version number in the high nibble of byte 6: 7 for a
+// v7, 4 for a facade or random UUID. It is only meaningful for the RFC 4122
+// variant.
+func (u UUID) Version() byte {
+	return u[6] >> 4
+}
+
+// Variant returns the variant of u.
+func (u UUID) Variant() Variant {
+	switch {
+	case u[8]&0x80 == 0:
+		return VariantNCS
+	case u[8]&0xC0 == 0x80:
+		return VariantRFC4122
+	case u[8]&0xE0 == 0xC0:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
+	}
+}
+
+// Timestamp returns the first 48 bits of u. For a v7 this is its creation
+// time in Unix milliseconds; for a facade it is the masked timestamp.
+func (u UUID) Timestamp() uint64 {
+	return rd48be(u[:6])
+}
+
+// RandA returns the 12-bit rand_a field, the low nibble of byte 6 and byte 7.
+// Encode leaves it unchanged.
+func (u UUID) RandA() uint16 {
+	return uint16(u[6]&0x0F)<<8 | uint16(u[7])
+}
+
+// RandB returns the 62-bit rand_b field, everything after the variant bits.
+// Encode leaves it unchanged.
+func (u UUID) RandB() uint64 {
+	return binary.BigEndian.Uint64(u[8:]) & 0x3FFFFFFFFFFFFFFF
+}
+
+// VariantBits returns the variant field of byte 8 exactly as stored, masked to
+// its defined width and left in place:
+//
+//	0xxxxxxx NCS          -> u[8] & 0x80 (0x00)
+//	10xxxxxx RFC 4122     -> u[8] & 0xC0 (0x80)
+//	110xxxxx Microsoft    -> u[8] & 0xE0 (0xC0)
+//	111xxxxx reserved     -> u[8] & 0xE0 (0xE0)
+//
+// This is intended for tooling that displays the raw bit pattern.
+func (u UUID) VariantBits() byte {
+	switch {
+	case u[8]&0x80 == 0:
+		return u[8] & 0x80
+	case u[8]&0xC0 == 0x80:
+		return u[8] & 0xC0
+	default:
+		return u[8] & 0xE0
+	}
+}
+
+// IsZero reports whether u is the zero value, for checking whether a UUID
+// field has been set. It is identical to u == Nil: a single comparison of the
+// array against the package-level zero value, with no allocation.
+func (u UUID) IsZero() bool {
+	return u == Nil
+}
+
+// IsNil reports whether u is the nil UUID. It is the same as IsZero.
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// IsMax reports whether u is the max UUID, with all bits set.
+func (u UUID) IsMax() bool {
+	return u == Max
+}
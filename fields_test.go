@@ -0,0 +1,153 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVariantBits(t *testing.T) {
+	tests := []struct {
+		name  string
+		byte8 byte
+		want  byte
+	}{
+		{"NCS", 0x7F, 0x00},
+		{"RFC 4122", 0xBF, 0x80},
+		{"RFC 4122 low", 0x80, 0x80},
+		{"Microsoft", 0xDF, 0xC0},
+		{"reserved", 0xFF, 0xE0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var u UUID
+			u[8] = tc.byte8
+			if got := u.VariantBits(); got != tc.want {
+				t.Errorf("VariantBits() with byte 8 = %02x: got %02x, want %02x", tc.byte8, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		want bool
+	}{
+		{"zero value", UUID{}, true},
+		{"Nil", Nil, true},
+		{"first byte set", UUID{0: 1}, false},
+		{"last byte set", UUID{15: 1}, false},
+		{"v7", NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.u.IsZero(); got != tc.want {
+				t.Errorf("IsZero = %v, want %v", got, tc.want)
+			}
+			if got := tc.u == Nil; got != tc.u.IsZero() {
+				t.Errorf("u == Nil = %v disagrees with IsZero", got)
+			}
+		})
+	}
+
+	var embedded struct{ ID UUID }
+	if !embedded.ID.IsZero() {
+		t.Error("unset struct field not reported as zero")
+	}
+	if n := testing.AllocsPerRun(100, func() { _ = embedded.ID.IsZero() }); n != 0 {
+		t.Errorf("IsZero allocates %v times", n)
+	}
+}
+
+func TestFieldAccessors(t *testing.T) {
+	u := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+
+	if got := u.Version(); got != 7 {
+		t.Errorf("Version = %d, want 7", got)
+	}
+	if got := u.Variant(); got != VariantRFC4122 {
+		t.Errorf("Variant = %v, want %v", got, VariantRFC4122)
+	}
+	if got := u.Timestamp(); got != 0x018f2d9f9a2a {
+		t.Errorf("Timestamp = %#x, want 0x018f2d9f9a2a", got)
+	}
+	if got := u.RandA(); got != 0x0def {
+		t.Errorf("RandA = %#x, want 0xdef", got)
+	}
+	if got := u.RandB(); got != 0x0c3f7b1a2c4d5e6f {
+		t.Errorf("RandB = %#x, want 0x0c3f7b1a2c4d5e6f", got)
+	}
+
+	// Encode changes only the timestamp and version.
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	f := Encode(u, key)
+	if f.Version() != 4 || f.RandA() != u.RandA() || f.RandB() != u.RandB() {
+		t.Errorf("facade fields: version %d, rand_a %#x, rand_b %#x", f.Version(), f.RandA(), f.RandB())
+	}
+}
+
+func TestVariant(t *testing.T) {
+	tests := []struct {
+		byte8 byte
+		want  Variant
+		name  string
+	}{
+		{0x00, VariantNCS, "NCS"},
+		{0x7F, VariantNCS, "NCS"},
+		{0x80, VariantRFC4122, "RFC 4122"},
+		{0xBF, VariantRFC4122, "RFC 4122"},
+		{0xC0, VariantMicrosoft, "Microsoft"},
+		{0xDF, VariantMicrosoft, "Microsoft"},
+		{0xE0, VariantFuture, "Future"},
+		{0xFF, VariantFuture, "Future"},
+	}
+
+	for _, tc := range tests {
+		var u UUID
+		u[8] = tc.byte8
+		if got := u.Variant(); got != tc.want {
+			t.Errorf("byte 8 %#02x: Variant = %v, want %v", tc.byte8, got, tc.want)
+		}
+		if got := tc.want.String(); got != tc.name {
+			t.Errorf("Variant(%d).String() = %q, want %q", tc.want, got, tc.name)
+		}
+	}
+	if got := Variant(9).String(); got != "Invalid" {
+		t.Errorf("Variant(9).String() = %q, want \"Invalid\"", got)
+	}
+}
+
+func TestNilMax(t *testing.T) {
+	if Nil.String() != "00000000-0000-0000-0000-000000000000" {
+		t.Errorf("Nil = %s", Nil)
+	}
+	if Max.String() != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("Max = %s", Max)
+	}
+	if !Nil.IsNil() || Nil.IsMax() || !Max.IsMax() || Max.IsNil() {
+		t.Error("IsNil/IsMax misreport Nil or Max")
+	}
+	v7 := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	if v7.IsNil() || v7.IsMax() {
+		t.Error("IsNil/IsMax true for a v7")
+	}
+
+	// Encode has no special cases: nil and max are masked like any input
+	// and do not survive a round trip, and EncodeStrict refuses them.
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	for _, u := range []UUID{Nil, Max} {
+		f := Encode(u, key)
+		if f.IsNil() || f.IsMax() {
+			t.Errorf("Encode(%s) = %s, want an ordinary facade", u, f)
+		}
+		if back := Decode(f, key); back == u {
+			t.Errorf("Decode(Encode(%s)) unexpectedly round-tripped", u)
+		}
+		if _, err := EncodeStrict(u, key); !errors.Is(err, ErrNonRFCVariant) {
+			t.Errorf("EncodeStrict(%s) error = %v, want ErrNonRFCVariant", u, err)
+		}
+	}
+}
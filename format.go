@@ -0,0 +1,164 @@
+package uuid47
+
+import "fmt"
+
+// UpperString returns the canonical form with uppercase hex digits, for
+// example 018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F.
+func (u UUID) UpperString() string {
+	var buf [36]byte
+	formatGroups(buf[:], u, '-', hexUpper)
+	return string(buf[:])
+}
+
+// Format implements fmt.Formatter. The verbs are:
+//
+//	%s, %v  canonical lowercase, as String
+//	%S      canonical uppercase, as UpperString
+//	%x      32 lowercase hex digits, as Hex
+//	%X      32 uppercase hex digits, as StringCompactUpper
+//	%q      canonical lowercase, double-quoted
+//
+// Width, precision and the '-' flag are honored as for strings, and %q's '#'
+// and '+' flags as for fmt's %q. Other verbs report an error in fmt's usual
+// %!verb(...) form.
+func (u UUID) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 's', 'v', 'q':
+		s = u.String()
+	case 'S':
+		s = u.UpperString()
+	case 'x':
+		s = u.Hex()
+	case 'X':
+		s = u.StringCompactUpper()
+	default:
+		fmt.Fprintf(f, "%%!%c(uuid47.UUID=%s)", verb, u.String())
+		return
+	}
+	if verb != 'q' {
+		verb = 's'
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), s)
+}
+
+// StringWithSep returns the 8-4-4-4-12 hex groups of the UUID joined by sep,
+// for example ':' for colon-separated output. A zero sep produces the 32
+// digits with no separators; '-' is equivalent to String.
+func (u UUID) StringWithSep(sep byte) string {
+	var buf [36]byte
+	n := formatGroups(buf[:], u, sep, hexLower)
+	return string(buf[:n])
+}
+
+// Hex returns the UUID as exactly 32 lowercase hex digits with no
+// separators, for example 018f2d9f9a2a7def8c3f7b1a2c4d5e6f, as used in
+// filenames and by systems that store the undashed form. Parse accepts this
+// form.
+func (u UUID) Hex() string {
+	var buf [32]byte
+	formatGroups(buf[:], u, 0, hexLower)
+	return string(buf[:])
+}
+
+// StringCompactUpper returns the UUID as exactly 32 uppercase hex digits with
+// no separators, as expected by some mainframe interfaces. Parse accepts
+// this form.
+func (u UUID) StringCompactUpper() string {
+	var buf [36]byte
+	n := formatGroups(buf[:], u, 0, hexUpper)
+	return string(buf[:n])
+}
+
+// PGHexLiteral returns the UUID's raw bytes in PostgreSQL's bytea hex format:
+// a backslash and 'x' followed by 32 lowercase hex digits, for example
+// \x018f2d9f9a2a7def8c3f7b1a2c4d5e6f. Note that COPY's text format treats
+// backslash as an escape, so the leading backslash must be doubled there;
+// COPY CSV and parameterized queries take the value as-is.
+func (u UUID) PGHexLiteral() string {
+	var buf [34]byte
+	buf[0], buf[1] = '\\', 'x'
+	formatGroups(buf[2:], u, 0, hexLower)
+	return string(buf[:])
+}
+
+// URN returns the UUID in the RFC 4122 URN form, for example
+// urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f. Parse accepts this form.
+func (u UUID) URN() string {
+	var buf [len(urnPrefix) + 36]byte
+	copy(buf[:], urnPrefix)
+	formatGroups(buf[len(urnPrefix):], u, '-', hexLower)
+	return string(buf[:])
+}
+
+// BracedString returns the canonical form wrapped in braces, for example
+// {018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}, as used for GUIDs by COM and
+// Active Directory tooling. Parse accepts this form.
+func (u UUID) BracedString() string {
+	return u.braced(hexLower)
+}
+
+// BracedStringUpper is like BracedString but with uppercase hex digits, as
+// written by the Windows registry and .NET's Guid.ToString("B").ToUpper().
+func (u UUID) BracedStringUpper() string {
+	return u.braced(hexUpper)
+}
+
+func (u UUID) braced(digits string) string {
+	var buf [38]byte
+	buf[0], buf[37] = '{', '}'
+	formatGroups(buf[1:], u, '-', digits)
+	return string(buf[:])
+}
+
+// ToLegacyInformixBytes returns the UUID's bytes with the five canonical
+// groups in reverse order, as persisted by a legacy Informix store. The bytes
+// within each group keep their order:
+//
+//	canonical: [0:4] [4:6] [6:8] [8:10] [10:16]
+//	legacy:    [10:16] [8:10] [6:8] [4:6] [0:4]
+//
+// so 018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f is stored as the bytes
+// 7b1a2c4d5e6f 8c3f 7def 9a2a 018f2d9f. FromLegacyInformixBytes reverses it.
+func (u UUID) ToLegacyInformixBytes() [16]byte {
+	var b [16]byte
+	copy(b[0:6], u[10:16])
+	copy(b[6:8], u[8:10])
+	copy(b[8:10], u[6:8])
+	copy(b[10:12], u[4:6])
+	copy(b[12:16], u[0:4])
+	return b
+}
+
+// FromLegacyInformixBytes converts bytes in the layout produced by
+// ToLegacyInformixBytes back to a UUID.
+func FromLegacyInformixBytes(b [16]byte) UUID {
+	var u UUID
+	copy(u[0:4], b[12:16])
+	copy(u[4:6], b[10:12])
+	copy(u[6:8], b[8:10])
+	copy(u[8:10], b[6:8])
+	copy(u[10:16], b[0:6])
+	return u
+}
+
+// EncodingSizes lists the length in bytes of a UUID in various encodings.
+type EncodingSizes struct {
+	Canonical  int // hyphenated hex, as produced by String
+	Hyphenless int // 32 hex digits, as produced by StringWithSep(0)
+	Binary     int // raw bytes
+	Base32     int // RFC 4648 base32 without padding
+	Base64URL  int // RFC 4648 URL-safe base64 without padding
+}
+
+// EncodingInfo returns the storage size of a UUID in each common encoding,
+// as a reference when choosing a column type.
+func EncodingInfo() EncodingSizes {
+	return EncodingSizes{
+		Canonical:  36,
+		Hyphenless: 32,
+		Binary:     16,
+		Base32:     26,
+		Base64URL:  22,
+	}
+}
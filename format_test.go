@@ -0,0 +1,204 @@
+package uuid47
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUpperString(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if got, want := u.UpperString(), "018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F"; got != want {
+		t.Errorf("UpperString = %s, want %s", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"},
+		{"%v", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"},
+		{"%+v", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"},
+		{"%S", "018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F"},
+		{"%x", "018f2d9f9a2a7def8c3f7b1a2c4d5e6f"},
+		{"%X", "018F2D9F9A2A7DEF8C3F7B1A2C4D5E6F"},
+		{"%q", `"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"`},
+		{"%#q", "`018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f`"},
+		{"%40s|", "    018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f|"},
+		{"%-40s|", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f    |"},
+		{"%.8s", "018f2d9f"},
+		{"%34x|", "  018f2d9f9a2a7def8c3f7b1a2c4d5e6f|"},
+		{"%d", "%!d(uuid47.UUID=018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f)"},
+	}
+	for _, tc := range tests {
+		if got := fmt.Sprintf(tc.format, u); got != tc.want {
+			t.Errorf("Sprintf(%q) = %s, want %s", tc.format, got, tc.want)
+		}
+	}
+
+	if got, want := fmt.Sprint([]UUID{u}), "[018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f]"; got != want {
+		t.Errorf("Sprint(slice) = %s, want %s", got, want)
+	}
+}
+
+func TestHex(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	got := u.Hex()
+
+	if want := "018f2d9f9a2a7def8c3f7b1a2c4d5e6f"; got != want {
+		t.Errorf("Hex = %s, want %s", got, want)
+	}
+	if got != hex.EncodeToString(u[:]) {
+		t.Errorf("Hex = %s, want hex of raw bytes %x", got, u[:])
+	}
+	back, err := Parse(got)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", got, err)
+	}
+	if back != u {
+		t.Errorf("roundtrip mismatch: %s != %s", back, u)
+	}
+}
+
+func TestStringCompactUpper(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	got := u.StringCompactUpper()
+	want := "018F2D9F9A2A7DEF8C3F7B1A2C4D5E6F"
+
+	if got != want {
+		t.Errorf("StringCompactUpper = %s, want %s", got, want)
+	}
+
+	back, err := Parse(got)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", got, err)
+	}
+	if back != u {
+		t.Errorf("roundtrip mismatch: %s != %s", back, u)
+	}
+}
+
+func TestLegacyInformixBytes(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	got := u.ToLegacyInformixBytes()
+	want := "7b1a2c4d5e6f8c3f7def9a2a018f2d9f"
+
+	if hex.EncodeToString(got[:]) != want {
+		t.Errorf("ToLegacyInformixBytes = %x, want %s", got, want)
+	}
+	if back := FromLegacyInformixBytes(got); back != u {
+		t.Errorf("roundtrip mismatch: %s != %s", back, u)
+	}
+}
+
+func TestStringWithSep(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name string
+		sep  byte
+		want string
+	}{
+		{"hyphen", '-', "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"},
+		{"colon", ':', "018f2d9f:9a2a:7def:8c3f:7b1a2c4d5e6f"},
+		{"none", 0, "018f2d9f9a2a7def8c3f7b1a2c4d5e6f"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := u.StringWithSep(tc.sep); got != tc.want {
+				t.Errorf("StringWithSep(%q) = %s, want %s", tc.sep, got, tc.want)
+			}
+		})
+	}
+
+	if u.StringWithSep('-') != u.String() {
+		t.Error("StringWithSep('-') differs from String()")
+	}
+}
+
+func TestPGHexLiteral(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	got := u.PGHexLiteral()
+
+	if want := `\x018f2d9f9a2a7def8c3f7b1a2c4d5e6f`; got != want {
+		t.Errorf("PGHexLiteral = %s, want %s", got, want)
+	}
+	if !strings.HasPrefix(got, `\x`) || len(got) != 34 {
+		t.Fatalf("PGHexLiteral = %q, want \\x prefix and 34 bytes", got)
+	}
+	raw, err := hex.DecodeString(got[2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, u[:]) {
+		t.Errorf("hex body %x does not match raw bytes %x", raw, u[:])
+	}
+}
+
+func TestURN(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	got := u.URN()
+
+	if want := "urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"; got != want {
+		t.Errorf("URN = %s, want %s", got, want)
+	}
+	back, err := Parse(got)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", got, err)
+	}
+	if back != u {
+		t.Errorf("roundtrip mismatch: %s != %s", back, u)
+	}
+}
+
+func TestBracedString(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	for _, tc := range []struct{ got, want string }{
+		{u.BracedString(), "{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}"},
+		{u.BracedStringUpper(), "{018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F}"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %s, want %s", tc.got, tc.want)
+		}
+		back, err := Parse(tc.got)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tc.got, err)
+		}
+		if back != u {
+			t.Errorf("roundtrip mismatch: %s != %s", back, u)
+		}
+	}
+}
+
+func TestEncodingInfo(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	info := EncodingInfo()
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"canonical", info.Canonical, len(u.String())},
+		{"hyphenless", info.Hyphenless, len(u.StringWithSep(0))},
+		{"binary", info.Binary, len(u)},
+		{"base32", info.Base32, len(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(u[:]))},
+		{"base64url", info.Base64URL, len(base64.RawURLEncoding.EncodeToString(u[:]))},
+	}
+
+	for _, tc := range tests {
+		if tc.got != tc.want {
+			t.Errorf("%s size = %d, want %d", tc.name, tc.got, tc.want)
+		}
+	}
+}
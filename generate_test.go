@@ -0,0 +1,193 @@
+package uuid47
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntropyAvailable(t *testing.T) {
+	if err := EntropyAvailable(); err != nil {
+		t.Fatalf("EntropyAvailable failed: %v", err)
+	}
+}
+
+func TestNewV7(t *testing.T) {
+	before := time.Now().UnixMilli()
+	u, err := NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().UnixMilli()
+
+	if u.Version() != 7 {
+		t.Errorf("Version should be 7, got %d", u.Version())
+	}
+	if (u[8] & 0xC0) != 0x80 {
+		t.Errorf("Variant bits incorrect: got %02x", u[8])
+	}
+	if ts := int64(rd48be(u[:6])); ts < before || ts > after { //nolint:gosec // G115: 48-bit value fits in int64
+		t.Errorf("timestamp %d outside [%d, %d]", ts, before, after)
+	}
+
+	u2, err := NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u == u2 {
+		t.Error("NewV7 generated identical UUIDs")
+	}
+}
+
+func TestGeneratorCounter(t *testing.T) {
+	clock := time.UnixMilli(0x018f2d9f9a2a)
+	g := NewGenerator()
+	g.now = func() time.Time { return clock }
+
+	var ids []UUID
+	for i := range 5 {
+		u, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := u.CounterValue(); int(got) != i {
+			t.Errorf("id %d: CounterValue = %d, want %d", i, got, i)
+		}
+		if u.Version() != 7 || (u[8]&0xC0) != 0x80 {
+			t.Errorf("id %d: %s is not a well-formed v7", i, u)
+		}
+		ids = append(ids, u)
+	}
+
+	// A new millisecond resets the counter.
+	clock = clock.Add(time.Millisecond)
+	u, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.CounterValue() != 0 {
+		t.Errorf("CounterValue after clock tick = %d, want 0", u.CounterValue())
+	}
+	ids = append(ids, u)
+
+	// A clock step backwards keeps counting from the last timestamp.
+	clock = clock.Add(-time.Second)
+	u, err = g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids = append(ids, u)
+
+	if !IsStrictlyOrdered(ids) {
+		t.Errorf("generated IDs are not strictly ordered: %v", ids)
+	}
+}
+
+func TestGeneratorZeroValue(t *testing.T) {
+	var g Generator
+	before := time.Now().UnixMilli()
+	a, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts := int64(a.Timestamp()); ts < before { //nolint:gosec // G115: 48-bit value fits in int64
+		t.Errorf("timestamp %d before %d", ts, before)
+	}
+	if Compare(a, b) >= 0 {
+		t.Errorf("zero-value Generator produced %s then %s, want increasing", a, b)
+	}
+}
+
+func TestGeneratorCounterOverflow(t *testing.T) {
+	clock := time.UnixMilli(0x018f2d9f9a2a)
+	g := NewGenerator()
+	g.now = func() time.Time { return clock }
+
+	var prev UUID
+	for i := range maxCounter + 2 {
+		u, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 && !IsStrictlyOrdered([]UUID{prev, u}) {
+			t.Fatalf("id %d (%s) does not sort after %s", i, u, prev)
+		}
+		prev = u
+	}
+
+	if got := rd48be(prev[:6]); got != 0x018f2d9f9a2b {
+		t.Errorf("timestamp after overflow = %012x, want %012x", got, 0x018f2d9f9a2b)
+	}
+	if prev.CounterValue() != 0 {
+		t.Errorf("CounterValue after overflow = %d, want 0", prev.CounterValue())
+	}
+}
+
+func TestIsLikelyOurV7(t *testing.T) {
+	fresh, err := NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := NewGenerator().New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms := func(t time.Time) uint64 { return uint64(t.UnixMilli()) } //nolint:gosec // G115: post-epoch test times
+
+	tests := []struct {
+		name string
+		u    UUID
+		want bool
+	}{
+		{"NewV7", fresh, true},
+		{"Generator", generated, true},
+		{"random v4", mustParse(t, "9b2c1f4e-0d3a-4c8b-a1e2-3f4d5c6b7a89"), false},
+		{"pre-2020 v7", NewV7FromParts(ms(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)), 1, 1), false},
+		{"future v7", NewV7FromParts(ms(time.Now().Add(time.Hour)), 1, 1), false},
+		{"zero timestamp v7", NewV7FromParts(0, 1, 1), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsLikelyOurV7(tc.u); got != tc.want {
+				t.Errorf("IsLikelyOurV7(%s) = %v, want %v", tc.u, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestV7Builder(t *testing.T) {
+	created := time.UnixMilli(0x018f2d9f9a2a)
+	want := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	got := V7Builder{}.Time(created).RandA(0x0def).RandB(0x0c3f7b1a2c4d5e6f).Build()
+	if got != want {
+		t.Errorf("Build = %s, want %s", got, want)
+	}
+	if got := (V7Builder{}).Millis(0x018f2d9f9a2a).RandA(0x0def).RandB(0x0c3f7b1a2c4d5e6f).Build(); got != want {
+		t.Errorf("Millis Build = %s, want %s", got, want)
+	}
+
+	// Setters return copies, so a template is not modified.
+	base := V7Builder{}.Time(created)
+	a, b := base.RandA(1).Build(), base.RandA(2).Build()
+	if a.RandA() != 1 || b.RandA() != 2 || base.Build().RandA() != 0 {
+		t.Errorf("template reuse: rand_a %#x, %#x, %#x", a.RandA(), b.RandA(), base.Build().RandA())
+	}
+
+	// Oversized fields are truncated rather than spilling into version or
+	// variant bits.
+	full := V7Builder{}.Millis(^uint64(0)).RandA(^uint16(0)).RandB(^uint64(0)).Build()
+	if full.String() != "ffffffff-ffff-7fff-bfff-ffffffffffff" {
+		t.Errorf("truncated Build = %s", full)
+	}
+	if zero := (V7Builder{}).Build(); zero.String() != "00000000-0000-7000-8000-000000000000" {
+		t.Errorf("zero Build = %s", zero)
+	}
+	if pre := (V7Builder{}).Time(time.Unix(-1, 0)).Build(); pre.Timestamp() != 0 {
+		t.Errorf("pre-epoch timestamp = %d, want 0", pre.Timestamp())
+	}
+}
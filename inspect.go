@@ -0,0 +1,53 @@
+package uuid47
+
+import (
+	"fmt"
+	"time"
+)
+
+// Report is the result of Inspect. The Version, Variant, RandA, and RandB
+// fields describe the input as given; the rest describe the result of
+// decoding it.
+type Report struct {
+	Input   UUID    // the UUID as given
+	Version byte    // version of Input, 4 for a facade
+	Variant Variant // variant of Input
+	Facade  bool    // whether Input is structurally a facade (IsPossibleFacade)
+
+	Decoded   UUID      // Input decoded under the key
+	Time      time.Time // creation time embedded in Decoded, in UTC
+	Plausible bool      // whether Time is within DefaultWindow as of the inspection time
+
+	RandA string // the 12-bit rand_a field as 3 hex digits
+	RandB string // the 62-bit rand_b field as 16 hex digits
+}
+
+// Inspect decodes u under key and reports what it finds, to help answer
+// "which key encoded this ID" during an incident: inspecting a facade under
+// each candidate key, the right one is usually the only one that yields a
+// plausible time. The decoded fields are filled in even when u is not a
+// facade, in which case they are meaningless.
+//
+// Like AuditLine, a Report exposes the stored v7 and its creation time and
+// should only be shown to key holders.
+func Inspect(u UUID, key Key) Report {
+	return InspectAt(u, key, time.Now())
+}
+
+// InspectAt is like Inspect but judges Plausible relative to now instead of
+// the current time.
+func InspectAt(u UUID, key Key, now time.Time) Report {
+	v7 := Decode(u, key)
+	t := unixMilliTime(v7)
+	return Report{
+		Input:     u,
+		Version:   u.Version(),
+		Variant:   u.Variant(),
+		Facade:    IsPossibleFacade(u),
+		Decoded:   v7,
+		Time:      t,
+		Plausible: DefaultWindow.check(v7, now) == nil,
+		RandA:     fmt.Sprintf("%03x", u.RandA()),
+		RandB:     fmt.Sprintf("%016x", u.RandB()),
+	}
+}
@@ -0,0 +1,53 @@
+package uuid47
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInspect(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := mustParse(t, "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f")
+
+	r := Inspect(facade, key)
+	want := Report{
+		Input:     facade,
+		Version:   4,
+		Variant:   VariantRFC4122,
+		Facade:    true,
+		Decoded:   mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"),
+		Time:      time.Date(2024, 4, 30, 6, 9, 45, 514_000_000, time.UTC),
+		Plausible: true,
+		RandA:     "def",
+		RandB:     "0c3f7b1a2c4d5e6f",
+	}
+	if r != want {
+		t.Errorf("Inspect = %+v, want %+v", r, want)
+	}
+
+	wrong := Inspect(facade, Key{K0: 0x1111111111111111, K1: 0x2222222222222222})
+	if wrong.Plausible {
+		t.Errorf("wrong key reported plausible time %v", wrong.Time)
+	}
+	if wrong.RandA != want.RandA || wrong.RandB != want.RandB {
+		t.Error("random fields depend on the key")
+	}
+
+	v7 := Inspect(want.Decoded, key)
+	if v7.Version != 7 || v7.Facade {
+		t.Errorf("v7 input: Version = %d, Facade = %v", v7.Version, v7.Facade)
+	}
+}
+
+func TestInspectAt(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := mustParse(t, "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f")
+	created := time.Date(2024, 4, 30, 6, 9, 45, 514_000_000, time.UTC)
+
+	if r := InspectAt(facade, key, created.Add(time.Hour)); !r.Plausible {
+		t.Error("facade inspected after creation reported implausible")
+	}
+	if r := InspectAt(facade, key, created.Add(-time.Hour)); r.Plausible {
+		t.Error("facade inspected an hour before creation reported plausible")
+	}
+}
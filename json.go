@@ -0,0 +1,148 @@
+package uuid47
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidJSON is returned by TransformJSON when its input is not valid
+// JSON.
+var ErrInvalidJSON = errors.New("invalid JSON document")
+
+// urnPrefix is the RFC 4122 URN namespace prefix for UUIDs.
+const urnPrefix = "urn:uuid:"
+
+// MarshalText implements encoding.TextMarshaler, emitting the canonical form.
+// encoding/json uses it to write the UUID as a JSON string, including as an
+// object key, and other text-based encoders pick it up the same way.
+func (u UUID) MarshalText() ([]byte, error) {
+	buf := make([]byte, 36)
+	formatGroups(buf, u, '-', hexLower)
+	return buf, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any form
+// accepted by Parse. A JSON null leaves the UUID unchanged.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// URNUUID is a UUID whose text and JSON form is the "urn:uuid:" URN rather
+// than the bare canonical string. Convert to and from UUID as needed; the
+// plain UUID type keeps its bare form.
+type URNUUID UUID
+
+// MarshalText implements encoding.TextMarshaler, emitting
+// "urn:uuid:<canonical>".
+func (u URNUUID) MarshalText() ([]byte, error) {
+	buf := make([]byte, len(urnPrefix)+36)
+	copy(buf, urnPrefix)
+	formatGroups(buf[len(urnPrefix):], UUID(u), '-', hexLower)
+	return buf, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the URN form
+// and any other form Parse accepts.
+func (u *URNUUID) UnmarshalText(text []byte) error {
+	return (*UUID)(u).UnmarshalText(text)
+}
+
+// TransformJSON rewrites every UUID-valued string in a JSON document, for a
+// gateway that must encode the IDs in arbitrary responses. With enc set, each
+// string value that Parse accepts as an RFC 4122 v7 is replaced by its facade
+// under key. Otherwise each value that DetectAndDecode recognizes as a facade
+// is replaced by its v7; other v4s, such as legacy random IDs, are kept.
+//
+// Rewritten values keep their form (canonical, braced, URN, or 32 hex digits)
+// but are written in lowercase. A 32-digit hex string that is not meant as a
+// UUID, such as a digest, is rewritten too if its bits happen to mark it as a
+// v7. Object keys, strings of other versions, and everything else are left
+// byte for byte as they were, including key order and whitespace, at any depth
+// of nesting; strings written with escapes are never UUIDs and are skipped. A
+// document that is not valid JSON returns ErrInvalidJSON.
+func TransformJSON(key Key, doc []byte, enc bool) ([]byte, error) {
+	if !json.Valid(doc) {
+		return nil, ErrInvalidJSON
+	}
+	out := make([]byte, 0, len(doc))
+	last := 0
+	for i := 0; i < len(doc); i++ {
+		if doc[i] != '"' {
+			continue
+		}
+		start := i + 1
+		escaped := false
+		for i = start; doc[i] != '"'; i++ {
+			if doc[i] == '\\' {
+				escaped = true
+				i++
+			}
+		}
+		switch i - start {
+		case 32, 36, 38, 45:
+		default:
+			continue
+		}
+		if escaped || isJSONObjectKey(doc[i+1:]) {
+			continue
+		}
+		u, err := ParseBytes(doc[start:i])
+		switch {
+		case err != nil:
+			continue
+		case enc && isV7(u):
+			u = Encode(u, key)
+		case !enc:
+			var ok bool
+			if u, ok = DetectAndDecode(u, key); !ok {
+				continue
+			}
+		default:
+			continue
+		}
+		out = append(out, doc[last:start]...)
+		out = appendForm(out, u, i-start)
+		last = i
+	}
+	return append(out, doc[last:]...), nil
+}
+
+// appendForm appends u to dst in the form Parse accepts for an n-byte
+// string: 32 hex digits, braced, URN, or otherwise canonical.
+func appendForm(dst []byte, u UUID, n int) []byte {
+	var buf [len(urnPrefix) + 36]byte
+	switch n {
+	case 32:
+		formatGroups(buf[:], u, 0, hexLower)
+	case 38:
+		buf[0], buf[37] = '{', '}'
+		formatGroups(buf[1:], u, '-', hexLower)
+	case len(buf):
+		copy(buf[:], urnPrefix)
+		formatGroups(buf[len(urnPrefix):], u, '-', hexLower)
+	default:
+		n = 36
+		formatGroups(buf[:], u, '-', hexLower)
+	}
+	return append(dst, buf[:n]...)
+}
+
+// isJSONObjectKey reports whether rest, the input following a string literal,
+// continues with a colon, meaning the string was an object key.
+func isJSONObjectKey(rest []byte) bool {
+	for _, c := range rest {
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case ':':
+			return true
+		}
+		return false
+	}
+	return false
+}
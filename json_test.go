@@ -0,0 +1,179 @@
+package uuid47
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestUUIDJSON(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	data, err := json.Marshal(struct{ ID UUID }{u})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"ID":"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var back struct{ ID UUID }
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.ID != u {
+		t.Errorf("roundtrip mismatch: %s != %s", back.ID, u)
+	}
+
+	var bad UUID
+	if err := json.Unmarshal([]byte(`"not-a-uuid"`), &bad); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Unmarshal error = %v, want ErrInvalidUUID", err)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`123`), &bad); !errors.As(err, &typeErr) {
+		t.Errorf("Unmarshal of number error = %v, want *json.UnmarshalTypeError", err)
+	}
+
+	kept := u
+	if err := json.Unmarshal([]byte(`null`), &kept); err != nil || kept != u {
+		t.Errorf("Unmarshal(null) = %s, %v, want %s unchanged", kept, err, u)
+	}
+}
+
+func TestUUIDJSONMapKey(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	data, err := json.Marshal(map[UUID]int{u: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f":1}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var back map[UUID]int
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if len(back) != 1 || back[u] != 1 {
+		t.Errorf("roundtrip = %v, want map[%s:1]", back, u)
+	}
+}
+
+func TestUUIDText(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	text, err := u.MarshalText()
+	if err != nil || string(text) != u.String() {
+		t.Errorf("MarshalText = %s, %v, want %s", text, err, u)
+	}
+	text, err = URNUUID(u).MarshalText()
+	if err != nil || string(text) != u.URN() {
+		t.Errorf("URNUUID.MarshalText = %s, %v, want %s", text, err, u.URN())
+	}
+
+	var back UUID
+	if err := back.UnmarshalText([]byte(u.BracedString())); err != nil || back != u {
+		t.Errorf("UnmarshalText = %s, %v, want %s", back, err, u)
+	}
+	if err := back.UnmarshalText([]byte("not-a-uuid")); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("UnmarshalText error = %v, want ErrInvalidUUID", err)
+	}
+}
+
+func TestURNUUIDJSON(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	const urn = `"urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"`
+
+	data, err := json.Marshal(URNUUID(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != urn {
+		t.Errorf("Marshal = %s, want %s", data, urn)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"urn", urn},
+		{"uppercase urn prefix", `"URN:UUID:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"`},
+		{"bare", `"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"`},
+		{"braced", `"{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got URNUUID
+			if err := json.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("Unmarshal(%s) failed: %v", tc.input, err)
+			}
+			if UUID(got) != u {
+				t.Errorf("Unmarshal(%s) = %s, want %s", tc.input, UUID(got), u)
+			}
+		})
+	}
+
+	var got URNUUID
+	if err := json.Unmarshal([]byte(`"urn\u001auuid\u001a018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"`), &got); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Unmarshal with control-character separators error = %v, want ErrInvalidUUID", err)
+	}
+}
+
+func TestTransformJSON(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7a := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	v7b := NewV7FromParts(0x018f2d9f9a2b, 0x0123, 0x0456).String()
+	fa := Encode(mustParse(t, v7a), key)
+	fb := Encode(mustParse(t, v7b), key).String()
+	ua := mustParse(t, v7a)
+
+	// %[1]s and %[2]s are the rewritten values, and %[4]s to %[6]s the same
+	// value in the braced, URN, and hyphenless forms. The object key %[3]s is
+	// a v7 that must be left alone, as must the v1, the random v4 (which
+	// decodes to an implausible time), the non-UUID strings, and the escaped
+	// spelling of a v7.
+	const tmpl = `{
+  "id": "%[1]s",
+  "name": "018f2d9f-not-a-uuid",
+  "%[3]s": "key position is untouched",
+  "count": 3,
+  "owner": {"id": "%[2]s", "tags": ["%[1]s", "x", null, true, 1.5e3]},
+  "legacy": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+  "random": "3b9d7e21-54c8-4f0a-b6e2-9c1d8a7f3e45",
+  "escaped": "\u0030\u0031\u0038f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+  "items": [{"ref" : "%[2]s"}, ["%[1]s"]],
+  "forms": ["%[4]s", "%[5]s", "%[6]s"]
+}`
+	plain := fmt.Sprintf(tmpl, v7a, v7b, v7a, ua.BracedString(), ua.URN(), ua.Hex())
+	encoded := fmt.Sprintf(tmpl, fa, fb, v7a, fa.BracedString(), fa.URN(), fa.Hex())
+
+	got, err := TransformJSON(key, []byte(plain), true)
+	if err != nil {
+		t.Fatalf("TransformJSON encode failed: %v", err)
+	}
+	if string(got) != encoded {
+		t.Errorf("TransformJSON encode =\n%s\nwant\n%s", got, encoded)
+	}
+
+	back, err := TransformJSON(key, got, false)
+	if err != nil {
+		t.Fatalf("TransformJSON decode failed: %v", err)
+	}
+	if string(back) != plain {
+		t.Errorf("TransformJSON decode =\n%s\nwant\n%s", back, plain)
+	}
+
+	// Decoding only touches v4s, so a document of v7s is unchanged.
+	if got, _ := TransformJSON(key, []byte(plain), false); string(got) != plain {
+		t.Errorf("decoding a document without facades changed it:\n%s", got)
+	}
+
+	for _, bad := range []string{"", "{", `{"id": "` + v7a + `"`, `["a",]`} {
+		if _, err := TransformJSON(key, []byte(bad), true); !errors.Is(err, ErrInvalidJSON) {
+			t.Errorf("TransformJSON(%q) error = %v, want ErrInvalidJSON", bad, err)
+		}
+	}
+}
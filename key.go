@@ -0,0 +1,113 @@
+package uuid47
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/dchest/siphash"
+)
+
+// ErrInvalidKey is returned when parsing a malformed key.
+var ErrInvalidKey = errors.New("invalid key format")
+
+// KeyFromHex parses a key written as 32 hex digits: the first 16 digits are
+// K0 and the last 16 are K1, each read as a big-endian (most significant
+// digit first) number. KeyFromHex(a+b) equals KeyFromParts(a, b).
+//
+// This is the natural "%016x%016x" rendering of K0 and K1. It is not the
+// byte layout used by NewRandomKey, which reads each half little-endian from
+// random bytes; the two are unrelated and need not agree.
+func KeyFromHex(s string) (Key, error) {
+	if len(s) != 32 {
+		return Key{}, ErrInvalidKey
+	}
+	return KeyFromParts(s[:16], s[16:])
+}
+
+// KeyFromParts parses K0 and K1 from separate 16-digit hex strings, each read
+// as a big-endian number, for configuration systems that store them apart.
+func KeyFromParts(k0Hex, k1Hex string) (Key, error) {
+	k0, ok := parseHex64(k0Hex)
+	if !ok {
+		return Key{}, ErrInvalidKey
+	}
+	k1, ok := parseHex64(k1Hex)
+	if !ok {
+		return Key{}, ErrInvalidKey
+	}
+	return Key{K0: k0, K1: k1}, nil
+}
+
+// parseHex64 decodes exactly 16 hex digits as a big-endian uint64.
+func parseHex64(s string) (uint64, bool) {
+	if len(s) != 16 {
+		return 0, false
+	}
+	var v uint64
+	for i := range 16 {
+		n, ok := hexNibble(s[i])
+		if !ok {
+			return 0, false
+		}
+		v = v<<4 | uint64(n)
+	}
+	return v, true
+}
+
+// subkeyDomain prefixes the SipHash input in DeriveSubkey so that derived
+// keys cannot coincide with any mask or tag computed under the master key.
+const subkeyDomain = "uuid47/subkey\x00"
+
+// DeriveSubkey derives a per-tenant key from master as the 128-bit SipHash of
+// a fixed domain prefix followed by tenantID. The same master and tenantID
+// always give the same subkey, so tenant keys need not be stored; different
+// tenants get unrelated keys, and a subkey does not reveal master.
+func DeriveSubkey(master Key, tenantID []byte) Key {
+	msg := append([]byte(subkeyDomain), tenantID...)
+	k0, k1 := siphash.Hash128(master.K0, master.K1, msg)
+	return Key{K0: k0, K1: k1}
+}
+
+// IsSubkeyOf reports whether sub is DeriveSubkey(master, tenantID), for
+// confirming that a provisioned tenant key came from the configured master.
+// The comparison runs in constant time.
+func IsSubkeyOf(sub, master Key, tenantID []byte) bool {
+	want := DeriveSubkey(master, tenantID)
+	var a, b [16]byte
+	binary.BigEndian.PutUint64(a[:8], sub.K0)
+	binary.BigEndian.PutUint64(a[8:], sub.K1)
+	binary.BigEndian.PutUint64(b[:8], want.K0)
+	binary.BigEndian.PutUint64(b[8:], want.K1)
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// Bounds and budget used by RecommendedBucketSize.
+const (
+	// idsPerKeyBudget is the number of facades one key may issue while keeping
+	// the chance of any two sharing their 74 random bits below about 2^-20.
+	idsPerKeyBudget = 1 << 27
+
+	minBucketSize = time.Second
+	maxBucketSize = 365 * 24 * time.Hour
+)
+
+// RecommendedBucketSize returns a conservative interval for rotating the
+// encoding key on a fixed time bucket, given the expected peak throughput.
+//
+// The mask applied to a timestamp depends only on the v7's 74 random bits.
+// If two v7s under the same key happen to share those bits, they share the
+// mask, and XORing their facades reveals the XOR of their timestamps. By the
+// birthday bound, after N IDs the chance of such a pair is about N²/2^75, so
+// capping each key at 2^27 (~134 million) IDs keeps it below roughly one in
+// a million. The interval is that budget divided by idsPerSecond, clamped to
+// between one second and one year; zero throughput returns the maximum.
+// Higher throughput never yields a longer interval.
+func RecommendedBucketSize(idsPerSecond uint64) time.Duration {
+	if idsPerSecond == 0 {
+		return maxBucketSize
+	}
+	d := time.Duration(idsPerKeyBudget * uint64(time.Second) / idsPerSecond) //nolint:gosec // G115: at most 2^27 seconds
+	return min(max(d, minBucketSize), maxBucketSize)
+}
@@ -0,0 +1,105 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyFromParts(t *testing.T) {
+	want := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	got, err := KeyFromParts("0123456789abcdef", "FEDCBA9876543210")
+	if err != nil {
+		t.Fatalf("KeyFromParts failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("KeyFromParts = %+v, want %+v", got, want)
+	}
+
+	fromHex, err := KeyFromHex("0123456789abcdef" + "fedcba9876543210")
+	if err != nil {
+		t.Fatalf("KeyFromHex failed: %v", err)
+	}
+	if fromHex != got {
+		t.Errorf("KeyFromHex = %+v, want %+v", fromHex, got)
+	}
+
+	bad := []struct {
+		name   string
+		k0, k1 string
+	}{
+		{"short k0", "0123456789abcde", "fedcba9876543210"},
+		{"long k1", "0123456789abcdef", "fedcba98765432100"},
+		{"bad hex", "0123456789abcdeg", "fedcba9876543210"},
+		{"prefixed", "0x23456789abcdef", "fedcba9876543210"},
+		{"empty", "", ""},
+	}
+	for _, tc := range bad {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := KeyFromParts(tc.k0, tc.k1); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("KeyFromParts(%q, %q) error = %v, want ErrInvalidKey", tc.k0, tc.k1, err)
+			}
+		})
+	}
+
+	if _, err := KeyFromHex("0123456789abcdef"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("KeyFromHex short error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestRecommendedBucketSize(t *testing.T) {
+	rates := []uint64{0, 1, 10, 100, 1000, 10_000, 100_000, 1_000_000, 100_000_000, 1 << 40}
+
+	prev := time.Duration(1<<63 - 1)
+	for _, r := range rates {
+		d := RecommendedBucketSize(r)
+		if d > prev {
+			t.Errorf("RecommendedBucketSize(%d) = %v, larger than %v for a lower rate", r, d, prev)
+		}
+		if d < time.Second || d > 365*24*time.Hour {
+			t.Errorf("RecommendedBucketSize(%d) = %v outside [1s, 1y]", r, d)
+		}
+		prev = d
+	}
+
+	// 1000 IDs/s exhausts the 2^27 budget in about 37 hours.
+	if d := RecommendedBucketSize(1000); d < 37*time.Hour || d > 38*time.Hour {
+		t.Errorf("RecommendedBucketSize(1000) = %v, want about 37h", d)
+	}
+}
+
+func TestDeriveSubkey(t *testing.T) {
+	master := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	otherMaster := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+
+	a := DeriveSubkey(master, []byte("tenant-a"))
+	if a != DeriveSubkey(master, []byte("tenant-a")) {
+		t.Error("DeriveSubkey is not deterministic")
+	}
+	if a == master {
+		t.Error("subkey equals master")
+	}
+
+	tests := []struct {
+		name   string
+		sub    Key
+		master Key
+		tenant string
+		want   bool
+	}{
+		{"correct derivation", a, master, "tenant-a", true},
+		{"wrong tenant", a, master, "tenant-b", false},
+		{"wrong master", a, otherMaster, "tenant-a", false},
+		{"master is not its own subkey", master, master, "tenant-a", false},
+		{"empty tenant", DeriveSubkey(master, nil), master, "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsSubkeyOf(tc.sub, tc.master, []byte(tc.tenant)); got != tc.want {
+				t.Errorf("IsSubkeyOf = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package uuid47
+
+import (
+	"crypto/md5"  //nolint:gosec // G501: MD5 is mandated by RFC 4122 for v3 UUIDs
+	"crypto/sha1" //nolint:gosec // G505: SHA-1 is mandated by RFC 4122 for v5 UUIDs
+	"encoding/binary"
+	"errors"
+	"hash"
+
+	"github.com/dchest/siphash"
+)
+
+// ErrNilNamespace is returned by NewV3Strict and NewV5Strict when the
+// namespace is the nil UUID.
+var ErrNilNamespace = errors.New("namespace is the nil UUID")
+
+// Predefined namespaces from RFC 4122 Appendix C for use with NewV3 and NewV5.
+var (
+	NamespaceDNS  = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceURL  = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceOID  = UUID{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceX500 = UUID{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+// NewV3 returns the RFC 4122 name-based UUID (version 3) for name within
+// namespace, computed as MD5 over the namespace bytes followed by the name.
+// Prefer NewV5 unless a legacy system requires v3.
+func NewV3(namespace UUID, name []byte) UUID {
+	return newNameBased(md5.New(), 3, namespace, name) //nolint:gosec // G401: MD5 is mandated by RFC 4122 for v3 UUIDs
+}
+
+// NewV5 returns the RFC 4122 name-based UUID (version 5) for name within
+// namespace, computed as SHA-1 over the namespace bytes followed by the name.
+//
+// Name-based UUIDs are unrelated to the v7/v4 facade scheme; this is provided
+// so callers that occasionally need them don't have to pull in a second
+// library.
+func NewV5(namespace UUID, name []byte) UUID {
+	return newNameBased(sha1.New(), 5, namespace, name) //nolint:gosec // G401: SHA-1 is mandated by RFC 4122 for v5 UUIDs
+}
+
+// NewV3Strict is like NewV3 but returns ErrNilNamespace if namespace is the
+// nil UUID, which is almost always a missing value rather than an intended
+// namespace. NewV3 accepts it, as RFC 4122 permits any namespace.
+func NewV3Strict(namespace UUID, name []byte) (UUID, error) {
+	if namespace == (UUID{}) {
+		return UUID{}, ErrNilNamespace
+	}
+	return NewV3(namespace, name), nil
+}
+
+// NewV5Strict is like NewV5 but returns ErrNilNamespace if namespace is the
+// nil UUID. NewV5 accepts it, as RFC 4122 permits any namespace.
+func NewV5Strict(namespace UUID, name []byte) (UUID, error) {
+	if namespace == (UUID{}) {
+		return UUID{}, ErrNilNamespace
+	}
+	return NewV5(namespace, name), nil
+}
+
+// HashToUUID deterministically maps arbitrary data to a version 4 UUID using
+// the 128-bit SipHash-2-4 of data under key. The same data and key always
+// give the same UUID, and without the key the output is unpredictable. Unlike
+// NewV5 this is keyed and not interoperable with other libraries; 6 of the
+// 128 hash bits are overwritten by the version and variant.
+func HashToUUID(data []byte, key Key) UUID {
+	h0, h1 := siphash.Hash128(key.K0, key.K1, data)
+
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], h0)
+	binary.BigEndian.PutUint64(u[8:], h1)
+	setVersion(&u, 4)
+	setVariantRFC4122(&u)
+	return u
+}
+
+// NextDeterministic returns the successor of seed in a reproducible
+// pseudo-random sequence of version 4 UUIDs: HashToUUID of the seed's 16
+// bytes under key. Feeding each result back in yields a chain that is fully
+// determined by the initial seed and key, for generating reproducible
+// synthetic datasets. It is not a substitute for random IDs in production.
+func NextDeterministic(seed UUID, key Key) UUID {
+	return HashToUUID(seed[:], key)
+}
+
+// newNameBased hashes namespace||name with h and stamps the given version and
+// the RFC 4122 variant onto the first 16 bytes of the digest.
+func newNameBased(h hash.Hash, ver byte, namespace UUID, name []byte) UUID {
+	h.Write(namespace[:])
+	h.Write(name)
+
+	var u UUID
+	copy(u[:], h.Sum(nil))
+	setVersion(&u, ver)
+	setVariantRFC4122(&u)
+	return u
+}
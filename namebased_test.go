@@ -0,0 +1,137 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNamespaces(t *testing.T) {
+	tests := []struct {
+		name string
+		ns   UUID
+		want string
+	}{
+		{"DNS", NamespaceDNS, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"URL", NamespaceURL, "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
+		{"OID", NamespaceOID, "6ba7b812-9dad-11d1-80b4-00c04fd430c8"},
+		{"X500", NamespaceX500, "6ba7b814-9dad-11d1-80b4-00c04fd430c8"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := Parse(tc.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.ns != want {
+				t.Errorf("Namespace%s = %s, want %s", tc.name, tc.ns, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewV5(t *testing.T) {
+	// Known vector, matches Python's uuid.uuid5(uuid.NAMESPACE_DNS, "www.example.com").
+	got := NewV5(NamespaceDNS, []byte("www.example.com"))
+	want := "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+
+	if got.String() != want {
+		t.Errorf("NewV5 = %s, want %s", got, want)
+	}
+	if got.Version() != 5 {
+		t.Errorf("Version should be 5, got %d", got.Version())
+	}
+	if (got[8] & 0xC0) != 0x80 {
+		t.Errorf("Variant bits incorrect: got %02x", got[8])
+	}
+}
+
+func TestNewV3(t *testing.T) {
+	// Known vector, matches Python's uuid.uuid3(uuid.NAMESPACE_DNS, "www.example.com").
+	name := []byte("www.example.com")
+	got := NewV3(NamespaceDNS, name)
+	want := "5df41881-3aed-3515-88a7-2f4a814cf09e"
+
+	if got.String() != want {
+		t.Errorf("NewV3 = %s, want %s", got, want)
+	}
+	if got.Version() != 3 {
+		t.Errorf("Version should be 3, got %d", got.Version())
+	}
+	if (got[8] & 0xC0) != 0x80 {
+		t.Errorf("Variant bits incorrect: got %02x", got[8])
+	}
+	if got == NewV5(NamespaceDNS, name) {
+		t.Error("NewV3 and NewV5 produced the same UUID")
+	}
+}
+
+func TestNameBasedNilNamespace(t *testing.T) {
+	name := []byte("www.example.com")
+
+	// The permissive constructors accept the nil namespace.
+	if NewV3(UUID{}, name) == NewV3(NamespaceDNS, name) {
+		t.Error("NewV3 ignored the nil namespace")
+	}
+	if NewV5(UUID{}, name) == NewV5(NamespaceDNS, name) {
+		t.Error("NewV5 ignored the nil namespace")
+	}
+
+	if _, err := NewV3Strict(UUID{}, name); !errors.Is(err, ErrNilNamespace) {
+		t.Errorf("NewV3Strict error = %v, want ErrNilNamespace", err)
+	}
+	if _, err := NewV5Strict(UUID{}, name); !errors.Is(err, ErrNilNamespace) {
+		t.Errorf("NewV5Strict error = %v, want ErrNilNamespace", err)
+	}
+
+	if u, err := NewV3Strict(NamespaceDNS, name); err != nil || u != NewV3(NamespaceDNS, name) {
+		t.Errorf("NewV3Strict = %s, %v; want %s, nil", u, err, NewV3(NamespaceDNS, name))
+	}
+	if u, err := NewV5Strict(NamespaceDNS, name); err != nil || u != NewV5(NamespaceDNS, name) {
+		t.Errorf("NewV5Strict = %s, %v; want %s, nil", u, err, NewV5(NamespaceDNS, name))
+	}
+}
+
+func TestHashToUUID(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	otherKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+
+	u := HashToUUID([]byte("customer-42"), key)
+	if u != HashToUUID([]byte("customer-42"), key) {
+		t.Error("HashToUUID is not deterministic")
+	}
+	if u.Version() != 4 {
+		t.Errorf("Version should be 4, got %d", u.Version())
+	}
+	if (u[8] & 0xC0) != 0x80 {
+		t.Errorf("Variant bits incorrect: got %02x", u[8])
+	}
+	if u == HashToUUID([]byte("customer-43"), key) {
+		t.Error("different inputs produced the same UUID")
+	}
+	if u == HashToUUID([]byte("customer-42"), otherKey) {
+		t.Error("different keys produced the same UUID")
+	}
+}
+
+func TestNextDeterministic(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	seed := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	if NextDeterministic(seed, key) != NextDeterministic(seed, key) {
+		t.Error("NextDeterministic is not deterministic")
+	}
+
+	seen := map[UUID]bool{seed: true}
+	cur := seed
+	for i := range 10000 {
+		cur = NextDeterministic(cur, key)
+		if seen[cur] {
+			t.Fatalf("chain cycled after %d steps", i+1)
+		}
+		if cur.Version() != 4 || (cur[8]&0xC0) != 0x80 {
+			t.Fatalf("step %d: %s is not a well-formed v4", i+1, cur)
+		}
+		seen[cur] = true
+	}
+}
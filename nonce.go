@@ -0,0 +1,41 @@
+package uuid47
+
+import "github.com/dchest/siphash"
+
+// EncodeWithNonce is Encode with a caller-supplied nonce mixed into the mask:
+// the SipHash input is the 74 random bits followed by nonce. Emitting the same
+// v7 under different nonces therefore yields unrelated timestamp fields, so a
+// single-use public ID cannot be replayed as, or correlated with, another
+// emission of the same record.
+//
+// The nonce is not stored in the facade. The decoder must know the exact
+// nonce, typically by storing it alongside the facade; DecodeWithNonce with
+// any other nonce returns a v7 with the wrong timestamp and no error. An
+// empty nonce produces the same facade as Encode.
+//
+// Only the timestamp is masked, as with Encode: rand_a and rand_b are shared
+// by every emission of the same v7 and remain linkable.
+func EncodeWithNonce(uuid UUID, key Key, nonce []byte) UUID {
+	out := uuid
+	wr48be(out[:6], rd48be(uuid[:6])^nonceMask48(uuid, key, nonce))
+	setVersion(&out, 4)
+	setVariantRFC4122(&out)
+	return out
+}
+
+// DecodeWithNonce reverses EncodeWithNonce given the same key and nonce.
+func DecodeWithNonce(facade UUID, key Key, nonce []byte) UUID {
+	out := facade
+	wr48be(out[:6], rd48be(facade[:6])^nonceMask48(facade, key, nonce))
+	setVersion(&out, 7)
+	setVariantRFC4122(&out)
+	return out
+}
+
+// nonceMask48 returns the low 48 bits of SipHash over the random bits of u
+// followed by nonce.
+func nonceMask48(u UUID, key Key, nonce []byte) uint64 {
+	sipMsg := buildSipInputFromV7(u)
+	msg := append(sipMsg[:], nonce...)
+	return siphash.Hash(key.K0, key.K1, msg) & 0x0000FFFFFFFFFFFF
+}
@@ -0,0 +1,35 @@
+package uuid47
+
+import "testing"
+
+func TestEncodeWithNonce(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	if got := EncodeWithNonce(v7, key, nil); got != Encode(v7, key) {
+		t.Errorf("empty nonce facade = %s, want Encode result %s", got, Encode(v7, key))
+	}
+
+	nonces := [][]byte{nil, {0}, {1}, []byte("emission-1"), []byte("emission-2")}
+	seen := make(map[UUID]bool, len(nonces))
+	for _, nonce := range nonces {
+		f := EncodeWithNonce(v7, key, nonce)
+		if f.Version() != 4 {
+			t.Errorf("nonce %q: facade version = %d, want 4", nonce, f.Version())
+		}
+		if seen[f] {
+			t.Errorf("nonce %q: duplicate facade %s", nonce, f)
+		}
+		seen[f] = true
+
+		if back := DecodeWithNonce(f, key, nonce); back != v7 {
+			t.Errorf("nonce %q: roundtrip = %s, want %s", nonce, back, v7)
+		}
+	}
+
+	// The wrong nonce decodes silently to a different timestamp.
+	f := EncodeWithNonce(v7, key, []byte("emission-1"))
+	if back := DecodeWithNonce(f, key, []byte("emission-2")); back == v7 {
+		t.Error("wrong nonce recovered the original v7")
+	}
+}
@@ -0,0 +1,62 @@
+package uuid47
+
+import (
+	"bytes"
+	"math/big"
+	"slices"
+)
+
+// Compare returns -1, 0, or +1 as a sorts before, equal to, or after b in
+// bytewise order. For v7s that is creation-time order, with ties within a
+// millisecond broken by the random bits. Its signature matches the cmp
+// argument of slices.SortFunc and slices.BinarySearchFunc.
+func Compare(a, b UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// Less reports whether u sorts before other, as Compare(u, other) < 0.
+func (u UUID) Less(other UUID) bool {
+	return Compare(u, other) < 0
+}
+
+// SortUUIDs sorts uuids in place in the order defined by Compare.
+func SortUUIDs(uuids []UUID) {
+	slices.SortFunc(uuids, Compare)
+}
+
+// IsStrictlyOrdered reports whether each UUID in uuids is strictly greater
+// than the one before it in bytewise order. For v7s generated by a monotonic
+// generator this is the expected invariant; empty and single-element slices
+// are trivially ordered.
+func IsStrictlyOrdered(uuids []UUID) bool {
+	for i := 1; i < len(uuids); i++ {
+		if Compare(uuids[i-1], uuids[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareIgnoringMeta compares u and other like Compare, but with the version
+// nibble (byte 6) and the two RFC variant bits (byte 8) cleared in both.
+// Since Encode only rewrites those fields and the timestamp, a v7 and its
+// facade differ under this comparison only in their first six bytes.
+func (u UUID) CompareIgnoringMeta(other UUID) int {
+	clearMeta := func(x *UUID) {
+		x[6] &= 0x0F
+		x[8] &= 0x3F
+	}
+	clearMeta(&u)
+	clearMeta(&other)
+	return Compare(u, other)
+}
+
+// DistanceTo returns other - u, treating both as unsigned 128-bit big-endian
+// integers. The result is positive when other sorts after u. For v7s the
+// magnitude is dominated by the timestamp difference; for facades it is
+// meaningless.
+func (u UUID) DistanceTo(other UUID) *big.Int {
+	a := new(big.Int).SetBytes(u[:])
+	b := new(big.Int).SetBytes(other[:])
+	return b.Sub(b, a)
+}
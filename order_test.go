@@ -0,0 +1,123 @@
+package uuid47
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	a := NewV7FromParts(100, 0x001, 0x001)
+	b := NewV7FromParts(100, 0x002, 0x000)
+	c := NewV7FromParts(101, 0x000, 0x000)
+
+	tests := []struct {
+		name string
+		x, y UUID
+		want int
+	}{
+		{"equal", a, a, 0},
+		{"same millisecond", a, b, -1},
+		{"later millisecond", c, b, 1},
+		{"nil before v7", Nil, a, -1},
+		{"max after v7", Max, c, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Compare(tc.x, tc.y); got != tc.want {
+				t.Errorf("Compare = %d, want %d", got, tc.want)
+			}
+			if got := tc.x.Less(tc.y); got != (tc.want < 0) {
+				t.Errorf("Less = %v, want %v", got, tc.want < 0)
+			}
+		})
+	}
+
+	uuids := []UUID{Max, c, a, Nil, b}
+	SortUUIDs(uuids)
+	if want := []UUID{Nil, a, b, c, Max}; !slices.Equal(uuids, want) {
+		t.Errorf("SortUUIDs = %v, want %v", uuids, want)
+	}
+	if !IsStrictlyOrdered(uuids) {
+		t.Error("sorted slice not strictly ordered")
+	}
+	if i, found := slices.BinarySearchFunc(uuids, b, Compare); !found || i != 2 {
+		t.Errorf("BinarySearchFunc = %d, %v; want 2, true", i, found)
+	}
+}
+
+func TestIsStrictlyOrdered(t *testing.T) {
+	a := NewV7FromParts(100, 0x001, 0x001)
+	b := NewV7FromParts(100, 0x002, 0x000)
+	c := NewV7FromParts(101, 0x000, 0x000)
+
+	tests := []struct {
+		name  string
+		input []UUID
+		want  bool
+	}{
+		{"empty", nil, true},
+		{"single", []UUID{a}, true},
+		{"ordered", []UUID{a, b, c}, true},
+		{"equal adjacent", []UUID{a, b, b, c}, false},
+		{"out of order", []UUID{a, c, b}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsStrictlyOrdered(tc.input); got != tc.want {
+				t.Errorf("IsStrictlyOrdered = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompareIgnoringMeta(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u7 := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	facade := Encode(u7, key)
+
+	if u7.CompareIgnoringMeta(facade) == 0 {
+		t.Error("v7 and facade compare equal despite different timestamps")
+	}
+
+	// With the timestamp bytes aligned, only version and variant differ.
+	aligned := u7
+	copy(aligned[:6], facade[:6])
+	if aligned == facade {
+		t.Fatal("aligned v7 unexpectedly identical to facade")
+	}
+	if c := aligned.CompareIgnoringMeta(facade); c != 0 {
+		t.Errorf("CompareIgnoringMeta = %d, want 0 once timestamps match", c)
+	}
+
+	// Differences outside the metadata bits still count.
+	other := aligned
+	other[15] ^= 1
+	if other.CompareIgnoringMeta(facade) == 0 {
+		t.Error("random bit difference ignored")
+	}
+}
+
+func TestDistanceTo(t *testing.T) {
+	a := NewV7FromParts(100, 0x001, 0x0000000000000010)
+	b := NewV7FromParts(100, 0x001, 0x0000000000000015)
+
+	if d := a.DistanceTo(b); d.Int64() != 5 {
+		t.Errorf("a.DistanceTo(b) = %v, want 5", d)
+	}
+	if d := b.DistanceTo(a); d.Int64() != -5 {
+		t.Errorf("b.DistanceTo(a) = %v, want -5", d)
+	}
+	if d := a.DistanceTo(a); d.Sign() != 0 {
+		t.Errorf("a.DistanceTo(a) = %v, want 0", d)
+	}
+
+	// One millisecond apart is 2^80 in the 128-bit value.
+	c := NewV7FromParts(101, 0x001, 0x0000000000000010)
+	want := new(big.Int).Lsh(big.NewInt(1), 80)
+	if d := a.DistanceTo(c); d.Cmp(want) != 0 {
+		t.Errorf("a.DistanceTo(c) = %v, want %v", d, want)
+	}
+}
@@ -0,0 +1,193 @@
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Reasons a UUID string fails to parse, reported in ParseError.Reason.
+var (
+	ErrWrongLength  = errors.New("wrong length")
+	ErrBadHyphen    = errors.New("missing hyphen")
+	ErrBadDelimiter = errors.New("missing brace or URN prefix")
+	ErrBadHexDigit  = errors.New("invalid hex digit")
+	ErrNotCanonical = errors.New("not canonical form")
+)
+
+// ParseError describes why a string is not a valid UUID, precisely enough for
+// an API to return a useful 400 response. It matches both ErrInvalidUUID and
+// its Reason with errors.Is.
+type ParseError struct {
+	Input  string // the string that failed to parse
+	Offset int    // byte offset of the problem; for ErrWrongLength, len(Input)
+	Reason error  // ErrWrongLength, ErrBadHyphen, ErrBadDelimiter, ErrBadHexDigit, or ErrNotCanonical
+}
+
+// Error returns a message naming the reason and where it occurred. The input
+// itself is omitted, since it may be untrusted and arbitrarily long.
+func (e *ParseError) Error() string {
+	if e.Reason == ErrWrongLength {
+		return fmt.Sprintf("%v: %v: got %d bytes, want 36, or 32, 38, or 45 for the hyphenless, braced, or URN form", ErrInvalidUUID, e.Reason, len(e.Input))
+	}
+	if e.Offset < 0 || e.Offset >= len(e.Input) {
+		return fmt.Sprintf("%v: %v at offset %d", ErrInvalidUUID, e.Reason, e.Offset)
+	}
+	return fmt.Sprintf("%v: %v: found %q at offset %d", ErrInvalidUUID, e.Reason, e.Input[e.Offset], e.Offset)
+}
+
+// Unwrap returns ErrInvalidUUID and the Reason.
+func (e *ParseError) Unwrap() []error {
+	return []error{ErrInvalidUUID, e.Reason}
+}
+
+// ErrUnsupportedType is returned by ParseAny for values it cannot coerce.
+var ErrUnsupportedType = errors.New("unsupported type for UUID")
+
+// ErrOffsetOutOfRange is returned by ParseAtOffset when the record is too
+// short to hold a UUID at the requested offset.
+var ErrOffsetOutOfRange = errors.New("UUID offset out of range")
+
+// ParseAny coerces a loosely typed value into a UUID. It accepts:
+//
+//   - string: parsed with Parse
+//   - []byte: 16 raw bytes are copied as-is, anything else is parsed as text
+//   - UUID or [16]byte: returned as-is
+//   - nil: returns the zero UUID
+//
+// Other types return an error wrapping ErrUnsupportedType. This centralizes
+// the coercion needed by generic deserialization code such as sql.Scanner
+// implementations.
+func ParseAny(v any) (UUID, error) {
+	switch x := v.(type) {
+	case nil:
+		return UUID{}, nil
+	case string:
+		return Parse(x)
+	case []byte:
+		if len(x) == 16 {
+			return UUID(x), nil
+		}
+		return ParseBytes(x)
+	case UUID:
+		return x, nil
+	case [16]byte:
+		return UUID(x), nil
+	default:
+		return UUID{}, fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+}
+
+// ParseAtOffset copies the 16 raw UUID bytes found at offset within a larger
+// binary record. It returns ErrOffsetOutOfRange unless
+// 0 <= offset && offset+16 <= len(record).
+func ParseAtOffset(record []byte, offset int) (UUID, error) {
+	if offset < 0 || offset > len(record)-16 {
+		return UUID{}, ErrOffsetOutOfRange
+	}
+	return UUID(record[offset : offset+16]), nil
+}
+
+// ParseStrict is like Parse but accepts only the 36-character lowercase
+// canonical form, as produced by String. Uppercase digits and the other forms
+// Parse accepts are rejected with ErrNotCanonical, so values that pass can be
+// used as cache or dedup keys without further normalization.
+func ParseStrict(s string) (UUID, error) {
+	if len(s) != 36 {
+		if _, err := Parse(s); err != nil {
+			return UUID{}, err
+		}
+		return UUID{}, &ParseError{Input: s, Offset: 0, Reason: ErrNotCanonical}
+	}
+	u, err := parseCanonical(s, 0)
+	if err != nil {
+		return UUID{}, err
+	}
+	for i := range len(s) {
+		if 'A' <= s[i] && s[i] <= 'F' {
+			return UUID{}, &ParseError{Input: s, Offset: i, Reason: ErrNotCanonical}
+		}
+	}
+	return u, nil
+}
+
+// ParseLenient parses a UUID in any form accepted by Parse, and additionally
+// repairs input from broken exporters with doubled or misplaced hyphens, as
+// long as it consists of exactly 32 hex digits in order, with hyphens as the
+// only other characters. Braces and the URN prefix are stripped first.
+// Otherwise the error wraps ErrInvalidUUID and describes what was wrong.
+func ParseLenient(s string) (UUID, error) {
+	if u, err := Parse(s); err == nil {
+		return u, nil
+	}
+	switch {
+	case len(s) >= 2 && s[0] == '{' && s[len(s)-1] == '}':
+		s = s[1 : len(s)-1]
+	case hasURNPrefix(s):
+		s = s[len(urnPrefix):]
+	}
+	return repairSeparators(s)
+}
+
+// repairSeparators drops every hyphen from s and decodes the remaining
+// characters, which must be exactly 32 hex digits.
+func repairSeparators(s string) (UUID, error) {
+	var u UUID
+	n := 0
+	for i := range len(s) {
+		c := s[i]
+		if c == '-' {
+			continue
+		}
+		v, ok := hexNibble(c)
+		if !ok {
+			return UUID{}, fmt.Errorf("%w: invalid character %q at offset %d", ErrInvalidUUID, c, i)
+		}
+		if n < 32 {
+			u[n/2] |= v << (4 * (1 - n%2))
+		}
+		n++
+	}
+	if n != 32 {
+		return UUID{}, fmt.Errorf("%w: found %d hex digits, want 32", ErrInvalidUUID, n)
+	}
+	return u, nil
+}
+
+// Normalize parses s in any form accepted by Parse and returns it in the
+// canonical lowercase form, so IDs from different clients compare equal as
+// strings.
+func Normalize(s string) (string, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// NormalizeAll parses each string with ParseLenient. The returned slice has
+// one entry per input, with the zero UUID at positions that failed to parse;
+// those positions are listed in the second result.
+func NormalizeAll(ss []string) ([]UUID, []int) {
+	out := make([]UUID, len(ss))
+	var failed []int
+	for i, s := range ss {
+		u, err := ParseLenient(s)
+		if err != nil {
+			failed = append(failed, i)
+			continue
+		}
+		out[i] = u
+	}
+	return out, failed
+}
+
+// ParsePathSegment parses a UUID taken from a URL path segment. Anything from
+// the first '?' onward is discarded, then a single trailing slash is trimmed,
+// and the remainder is parsed with Parse.
+func ParsePathSegment(s string) (UUID, error) {
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		s = s[:i]
+	}
+	return Parse(strings.TrimSuffix(s, "/"))
+}
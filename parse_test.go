@@ -0,0 +1,345 @@
+package uuid47
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantReason error
+		wantOffset int
+		wantMsg    string
+	}{
+		{"empty", "", ErrWrongLength, 0, "invalid UUID format: wrong length: got 0 bytes, want 36, or 32, 38, or 45 for the hyphenless, braced, or URN form"},
+		{"too short", "018f2d9f-9a2a-7def-8c3f", ErrWrongLength, 23, "invalid UUID format: wrong length: got 23 bytes, want 36, or 32, 38, or 45 for the hyphenless, braced, or URN form"},
+		{"too long", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f0", ErrWrongLength, 37, "invalid UUID format: wrong length: got 37 bytes, want 36, or 32, 38, or 45 for the hyphenless, braced, or URN form"},
+		{"missing first hyphen", "018f2d9f_9a2a-7def-8c3f-7b1a2c4d5e6f", ErrBadHyphen, 8, `invalid UUID format: missing hyphen: found '_' at offset 8`},
+		{"missing fourth hyphen", "018f2d9f-9a2a-7def-8c3f07b1a2c4d5e6f", ErrBadHyphen, 23, `invalid UUID format: missing hyphen: found '0' at offset 23`},
+		{"bad high digit", "018f2d9f-9a2a-zdef-8c3f-7b1a2c4d5e6f", ErrBadHexDigit, 14, `invalid UUID format: invalid hex digit: found 'z' at offset 14`},
+		{"bad low digit", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g", ErrBadHexDigit, 35, `invalid UUID format: invalid hex digit: found 'g' at offset 35`},
+		{"stray hyphen", "018f2d9f-9a2a-7def-8c3f-7b1a2c-d5e6f", ErrBadHexDigit, 30, `invalid UUID format: invalid hex digit: found '-' at offset 30`},
+		{"braced bad hyphen", "{018f2d9f-9a2a-7def+8c3f-7b1a2c4d5e6f}", ErrBadHyphen, 19, `invalid UUID format: missing hyphen: found '+' at offset 19`},
+		{"unbalanced braces", "{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f)", ErrBadDelimiter, 37, `invalid UUID format: missing brace or URN prefix: found ')' at offset 37`},
+		{"missing open brace", "(018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}", ErrBadDelimiter, 0, `invalid UUID format: missing brace or URN prefix: found '(' at offset 0`},
+		{"URN bad digit", "urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6q", ErrBadHexDigit, 44, `invalid UUID format: invalid hex digit: found 'q' at offset 44`},
+		{"control-character URN separators", "urn\x1auuid\x1a018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", ErrBadDelimiter, 3, `invalid UUID format: missing brace or URN prefix: found '\x1a' at offset 3`},
+		{"wrong URN namespace", "urn:oid:0018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", ErrBadDelimiter, 4, `invalid UUID format: missing brace or URN prefix: found 'o' at offset 4`},
+		{"misspelled URN prefix", "urn:uuix:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", ErrBadDelimiter, 7, `invalid UUID format: missing brace or URN prefix: found 'x' at offset 7`},
+		{"hyphenless bad digit", "018f2d9f9a2a7def8c3f7b1a2c4d5e6-", ErrBadHexDigit, 31, `invalid UUID format: invalid hex digit: found '-' at offset 31`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Parse(%q) error = %v, want *ParseError", tc.input, err)
+			}
+			if !errors.Is(err, ErrInvalidUUID) || !errors.Is(err, tc.wantReason) {
+				t.Errorf("error %v does not match ErrInvalidUUID and %v", err, tc.wantReason)
+			}
+			if pe.Input != tc.input || pe.Offset != tc.wantOffset || pe.Reason != tc.wantReason {
+				t.Errorf("ParseError = %+v, want offset %d reason %v", *pe, tc.wantOffset, tc.wantReason)
+			}
+			if err.Error() != tc.wantMsg {
+				t.Errorf("Error() = %q, want %q", err.Error(), tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseErrorOutOfRange(t *testing.T) {
+	tests := []struct {
+		err  *ParseError
+		want string
+	}{
+		{&ParseError{Reason: ErrBadHexDigit}, "invalid UUID format: invalid hex digit at offset 0"},
+		{&ParseError{Input: "018f", Offset: 4, Reason: ErrBadHyphen}, "invalid UUID format: missing hyphen at offset 4"},
+		{&ParseError{Input: "018f", Offset: -1, Reason: ErrBadHexDigit}, "invalid UUID format: invalid hex digit at offset -1"},
+	}
+	for _, tc := range tests {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("Error() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	const s = "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	u, err := ParseStrict(s)
+	if err != nil || u != mustParse(t, s) {
+		t.Fatalf("ParseStrict(%q) = %s, %v", s, u, err)
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		wantReason error
+		wantOffset int
+	}{
+		{"uppercase", "018f2d9f-9a2a-7dEf-8c3f-7b1a2c4d5e6f", ErrNotCanonical, 16},
+		{"braced", "{" + s + "}", ErrNotCanonical, 0},
+		{"URN", "urn:uuid:" + s, ErrNotCanonical, 0},
+		{"hyphenless", "018f2d9f9a2a7def8c3f7b1a2c4d5e6f", ErrNotCanonical, 0},
+		{"bad hyphen", "018f2d9f-9a2a-7def_8c3f-7b1a2c4d5e6f", ErrBadHyphen, 18},
+		{"bad digit", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6x", ErrBadHexDigit, 35},
+		{"wrong length", s[:35], ErrWrongLength, 35},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseStrict(tc.input)
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("ParseStrict(%q) error = %v, want *ParseError", tc.input, err)
+			}
+			if !errors.Is(err, ErrInvalidUUID) || pe.Reason != tc.wantReason || pe.Offset != tc.wantOffset {
+				t.Errorf("ParseError = %+v, want offset %d reason %v", *pe, tc.wantOffset, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	const s = "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	want := mustParse(t, s)
+
+	tests := []struct {
+		name  string
+		input any
+		want  UUID
+	}{
+		{"string", s, want},
+		{"text bytes", []byte(s), want},
+		{"raw bytes", want[:], want},
+		{"UUID", want, want},
+		{"array", [16]byte(want), want},
+		{"nil", nil, UUID{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseAny(tc.input)
+			if err != nil {
+				t.Fatalf("ParseAny failed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseAny = %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	errTests := []struct {
+		name  string
+		input any
+		want  error
+	}{
+		{"unsupported type", 42, ErrUnsupportedType},
+		{"bad string", "not-a-uuid", ErrInvalidUUID},
+		{"short bytes", []byte{1, 2, 3}, ErrInvalidUUID},
+	}
+
+	for _, tc := range errTests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseAny(tc.input); !errors.Is(err, tc.want) {
+				t.Errorf("ParseAny error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseAtOffset(t *testing.T) {
+	want := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	record := make([]byte, 40)
+	copy(record[8:], want[:])
+	copy(record[24:], want[:])
+
+	tests := []struct {
+		name    string
+		offset  int
+		wantErr bool
+	}{
+		{"valid offset", 8, false},
+		{"exact end", 24, false},
+		{"one past end", 25, true},
+		{"beyond record", 100, true},
+		{"negative", -1, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseAtOffset(record, tc.offset)
+			if tc.wantErr {
+				if !errors.Is(err, ErrOffsetOutOfRange) {
+					t.Errorf("ParseAtOffset(%d) error = %v, want ErrOffsetOutOfRange", tc.offset, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAtOffset(%d) failed: %v", tc.offset, err)
+			}
+			if got != want {
+				t.Errorf("ParseAtOffset(%d) = %s, want %s", tc.offset, got, want)
+			}
+		})
+	}
+
+	if _, err := ParseAtOffset(make([]byte, 8), 0); !errors.Is(err, ErrOffsetOutOfRange) {
+		t.Errorf("short record error = %v, want ErrOffsetOutOfRange", err)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	want := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"canonical", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", false},
+		{"hyphenless lowercase", "018f2d9f9a2a7def8c3f7b1a2c4d5e6f", false},
+		{"hyphenless uppercase", "018F2D9F9A2A7DEF8C3F7B1A2C4D5E6F", false},
+		{"braced", "{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}", false},
+		{"braced uppercase", "{018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F}", false},
+		{"urn", "urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", false},
+		{"uppercase urn", "URN:UUID:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", false},
+		{"hyphenless bad hex", "018f2d9f9a2a7def8c3f7b1a2c4d5e6g", true},
+		{"doubled hyphens", "018f2d9f--9a2a--7def--8c3f--7b1a2c4d5e6f", false},
+		{"misplaced hyphen", "018f2d9f9-a2a-7def-8c3f-7b1a2c4d5e6f", false},
+		{"trailing hyphen", "018f2d9f9a2a7def8c3f7b1a2c4d5e6f-", false},
+		{"too many hex digits", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f0", true},
+		{"too few hex digits", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6", true},
+		{"foreign separator", "018f2d9f_9a2a_7def_8c3f_7b1a2c4d5e6f", true},
+		{"unbalanced brace", "{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", true},
+		{"urn without uuid", "urn:uuid:", true},
+		{"control-character urn separators", "urn\x1auuid\x1a018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", true},
+		{"too short", "018f2d9f9a2a7def8c3f7b1a2c4d5e6", true},
+		{"empty", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseLenient(tc.input)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidUUID) {
+					t.Errorf("ParseLenient(%q) error = %v, want ErrInvalidUUID", tc.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLenient(%q) failed: %v", tc.input, err)
+			}
+			if got != want {
+				t.Errorf("ParseLenient(%q) = %s, want %s", tc.input, got, want)
+			}
+		})
+	}
+}
+
+func TestParsePathSegment(t *testing.T) {
+	want := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"bare", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", false},
+		{"trailing slash", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f/", false},
+		{"query suffix", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f?expand=true", false},
+		{"slash and query", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f/?a=b", false},
+		{"two trailing slashes", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f//", true},
+		{"nested path", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f/items", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePathSegment(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParsePathSegment(%q) should have failed", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePathSegment(%q) failed: %v", tc.input, err)
+			}
+			if got != want {
+				t.Errorf("ParsePathSegment(%q) = %s, want %s", tc.input, got, want)
+			}
+		})
+	}
+}
+
+func TestParseLenientDiagnostics(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e", "found 30 hex digits, want 32"},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f00", "found 34 hex digits, want 32"},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6z", "invalid character 'z' at offset 35"},
+	}
+
+	for _, tc := range tests {
+		_, err := ParseLenient(tc.input)
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseLenient(%q) error = %v, want ErrInvalidUUID", tc.input, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ParseLenient(%q) error = %q, want it to mention %q", tc.input, err, tc.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	const want = "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	for _, s := range []string{
+		want,
+		"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F",
+		"{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}",
+		"URN:UUID:018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F",
+		"018f2d9f9a2a7def8c3f7b1a2c4d5e6f",
+	} {
+		got, err := Normalize(s)
+		if err != nil || got != want {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", s, got, err, want)
+		}
+	}
+
+	if _, err := Normalize("018f2d9f-9a2a-7def-8c3f"); !errors.Is(err, ErrWrongLength) {
+		t.Errorf("Normalize(short) error = %v, want ErrWrongLength", err)
+	}
+}
+
+func TestNormalizeAll(t *testing.T) {
+	want := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	inputs := []string{
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"018F2D9F9A2A7DEF8C3F7B1A2C4D5E6F",
+		"{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}",
+		"urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"garbage",
+	}
+
+	got, failed := NormalizeAll(inputs)
+	if len(got) != len(inputs) {
+		t.Fatalf("got %d results, want %d", len(got), len(inputs))
+	}
+	for i := range 4 {
+		if got[i] != want {
+			t.Errorf("NormalizeAll[%d] (%q) = %s, want %s", i, inputs[i], got[i], want)
+		}
+	}
+	if got[4] != (UUID{}) {
+		t.Errorf("failed entry = %s, want zero UUID", got[4])
+	}
+	if len(failed) != 1 || failed[0] != 4 {
+		t.Errorf("failed indices = %v, want [4]", failed)
+	}
+}
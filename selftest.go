@@ -0,0 +1,63 @@
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dchest/siphash"
+)
+
+// ErrSelfTestFailed is wrapped by the error SelfTest returns on a mismatch.
+var ErrSelfTestFailed = errors.New("self-test failed")
+
+// sipHashVectors are the SipHash-2-4 reference outputs for the key
+// 00 01 .. 0f and messages 00 01 .. (n-1), from the C implementation's tests.
+var sipHashVectors = [...]uint64{
+	0x726fdb47dd0e0e31, 0x74f839c593dc67fd, 0x0d6c8009d9a94f5a,
+	0x85676696d7fb7e2d, 0xcf2794e0277187b7, 0x18765564cd99a68d,
+	0xcbc9466e58fee3ce, 0xab0200f58b01d137, 0x93f5f5799a932462,
+	0x9e0082df0ba9e4b0, 0x7a5dbbc594ddb9f3, 0xf4b32f46226bada7,
+	0x751e8fbc860ee5fb,
+}
+
+// encodeVectors are v7/facade pairs generated by the C implementation under
+// the key {0x0123456789abcdef, 0xfedcba9876543210}.
+var encodeVectors = [...]struct{ v7, facade UUID }{
+	{
+		UUID{0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef, 0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f},
+		UUID{0x24, 0x63, 0xc7, 0x80, 0x7f, 0xca, 0x4d, 0xef, 0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f},
+	},
+	{
+		UUID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x70, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		UUID{0x22, 0xd9, 0x71, 0x26, 0x96, 0x09, 0x40, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	},
+}
+
+// SelfTest checks the SipHash dependency against its published test vectors
+// and Encode/Decode against vectors from the C reference implementation. It
+// is cheap enough to call at startup in high-assurance deployments, where it
+// catches a mismatched or tampered dependency before any facade is issued.
+// The returned error wraps ErrSelfTestFailed.
+func SelfTest() error {
+	const k0, k1 = 0x0706050403020100, 0x0f0e0d0c0b0a0908
+	var msg [len(sipHashVectors)]byte
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for n, want := range sipHashVectors {
+		if got := siphash.Hash(k0, k1, msg[:n]); got != want {
+			return fmt.Errorf("%w: SipHash of %d bytes = %016x, want %016x", ErrSelfTestFailed, n, got, want)
+		}
+	}
+
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	for _, v := range encodeVectors {
+		if got := Encode(v.v7, key); got != v.facade {
+			return fmt.Errorf("%w: Encode(%s) = %s, want %s", ErrSelfTestFailed, v.v7, got, v.facade)
+		}
+		if got := Decode(v.facade, key); got != v.v7 {
+			return fmt.Errorf("%w: Decode(%s) = %s, want %s", ErrSelfTestFailed, v.facade, got, v.v7)
+		}
+	}
+	return nil
+}
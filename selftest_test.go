@@ -0,0 +1,22 @@
+package uuid47
+
+import "testing"
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatalf("SelfTest failed: %v", err)
+	}
+}
+
+func TestSelfTestVectorsMatchC(t *testing.T) {
+	// Keep the embedded vectors in sync with TestExactCCompatibility.
+	pairs := []struct{ v7, facade string }{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f"},
+		{"00000000-0000-7000-8000-000000000000", "22d97126-9609-4000-8000-000000000000"},
+	}
+	for i, p := range pairs {
+		if encodeVectors[i].v7 != mustParse(t, p.v7) || encodeVectors[i].facade != mustParse(t, p.facade) {
+			t.Errorf("encodeVectors[%d] does not match %s -> %s", i, p.v7, p.facade)
+		}
+	}
+}
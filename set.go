@@ -0,0 +1,35 @@
+package uuid47
+
+// Difference returns the UUIDs in a that are not in b, in order of first
+// appearance in a. Duplicates in a are reported once.
+func Difference(a, b []UUID) []UUID {
+	exclude := make(map[UUID]struct{}, len(b))
+	for _, u := range b {
+		exclude[u] = struct{}{}
+	}
+	var out []UUID
+	for _, u := range a {
+		if _, ok := exclude[u]; !ok {
+			out = append(out, u)
+			exclude[u] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Intersection returns the UUIDs present in both a and b, in order of first
+// appearance in a. Duplicates in a are reported once.
+func Intersection(a, b []UUID) []UUID {
+	include := make(map[UUID]bool, len(b))
+	for _, u := range b {
+		include[u] = true
+	}
+	var out []UUID
+	for _, u := range a {
+		if include[u] {
+			out = append(out, u)
+			include[u] = false
+		}
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package uuid47
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDifference(t *testing.T) {
+	u := func(i uint64) UUID { return NewV7FromParts(i, 0, i) }
+	a := []UUID{u(5), u(1), u(3), u(1), u(4)}
+	b := []UUID{u(3), u(9)}
+
+	got := Difference(a, b)
+	want := []UUID{u(5), u(1), u(4)}
+	if !slices.Equal(got, want) {
+		t.Errorf("Difference = %v, want %v", got, want)
+	}
+
+	if got := Difference(a, a); len(got) != 0 {
+		t.Errorf("Difference(a, a) = %v, want empty", got)
+	}
+	if got := Difference(nil, b); len(got) != 0 {
+		t.Errorf("Difference(nil, b) = %v, want empty", got)
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	u := func(i uint64) UUID { return NewV7FromParts(i, 0, i) }
+	a := []UUID{u(5), u(1), u(3), u(1), u(4)}
+
+	got := Intersection(a, []UUID{u(4), u(1), u(9)})
+	want := []UUID{u(1), u(4)}
+	if !slices.Equal(got, want) {
+		t.Errorf("Intersection = %v, want %v", got, want)
+	}
+
+	if got := Intersection(a, []UUID{u(7), u(8)}); len(got) != 0 {
+		t.Errorf("Intersection of disjoint slices = %v, want empty", got)
+	}
+}
@@ -0,0 +1,56 @@
+package uuid47
+
+import "github.com/dchest/siphash"
+
+// hash64Key is a fixed, public SipHash key used by Hash64. It provides no
+// secrecy; it only makes Hash64 stable across processes and releases.
+var hash64Key = Key{K0: 0x0706050403020100, K1: 0x0f0e0d0c0b0a0908}
+
+// Shard maps the UUID onto one of n buckets using its 62 rand_b bits.
+//
+// Encode never touches rand_b, so a UUIDv7 and its facade always land in the
+// same shard. This makes it safe to shard on whichever form a service happens
+// to hold. Shard panics if n <= 0.
+func (u UUID) Shard(n int) int {
+	if n <= 0 {
+		panic("uuid47: invalid shard count")
+	}
+	return int(u.RandB() % uint64(n)) //nolint:gosec // G115: result is < n, which is an int
+}
+
+// BucketHistogram counts how many of the given facades fall into each of the
+// given number of buckets according to Shard. It is an analysis helper for
+// confirming that sharding on facades stays balanced. Like Shard, it panics
+// if buckets <= 0, even when facades is empty.
+func BucketHistogram(facades []UUID, buckets int) []int {
+	if buckets <= 0 {
+		panic("uuid47: invalid bucket count")
+	}
+	counts := make([]int, buckets)
+	for _, f := range facades {
+		counts[f.Shard(buckets)]++
+	}
+	return counts
+}
+
+// CollisionRate returns the fraction of buckets that receive more than one of
+// the given facades under Shard. A high rate for the intended bucket count
+// means facade-based sharding will hotspot. Like Shard, it panics if
+// buckets <= 0.
+func CollisionRate(facades []UUID, buckets int) float64 {
+	collided := 0
+	for _, c := range BucketHistogram(facades, buckets) {
+		if c > 1 {
+			collided++
+		}
+	}
+	return float64(collided) / float64(buckets)
+}
+
+// Hash64 returns a stable 64-bit hash of all 16 bytes of the UUID, suitable
+// for bloom filters and hash-join keys. It uses SipHash-2-4 under a fixed
+// public key, so it is not a secret or collision-resistant identifier: anyone
+// can compute it, and unrelated UUIDs may share a hash.
+func (u UUID) Hash64() uint64 {
+	return siphash.Hash(hash64Key.K0, hash64Key.K1, u[:])
+}
@@ -0,0 +1,125 @@
+package uuid47
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+)
+
+// randomFacades returns n facades of random v7 inputs under key.
+func randomFacades(t testing.TB, n int, key Key) []UUID {
+	t.Helper()
+	out := make([]UUID, n)
+	for i := range out {
+		var u UUID
+		if _, err := rand.Read(u[:]); err != nil {
+			t.Fatal(err)
+		}
+		setVersion(&u, 7)
+		setVariantRFC4122(&u)
+		out[i] = Encode(u, key)
+	}
+	return out
+}
+
+func TestShardMatchesAcrossEncode(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u7, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	facade := Encode(u7, key)
+
+	for _, n := range []int{1, 2, 7, 64, 1000} {
+		if got, want := facade.Shard(n), u7.Shard(n); got != want {
+			t.Errorf("Shard(%d): facade=%d, v7=%d", n, got, want)
+		}
+		if s := u7.Shard(n); s < 0 || s >= n {
+			t.Errorf("Shard(%d) = %d out of range", n, s)
+		}
+	}
+}
+
+func TestBucketHistogram(t *testing.T) {
+	const (
+		n       = 64000
+		buckets = 16
+	)
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	counts := BucketHistogram(randomFacades(t, n, key), buckets)
+
+	if len(counts) != buckets {
+		t.Fatalf("got %d buckets, want %d", len(counts), buckets)
+	}
+
+	total := 0
+	mean := n / buckets
+	for i, c := range counts {
+		total += c
+		// Allow 10% deviation from the mean; the expected spread is ~1.6%.
+		if c < mean*9/10 || c > mean*11/10 {
+			t.Errorf("bucket %d has %d facades, want about %d", i, c, mean)
+		}
+	}
+	if total != n {
+		t.Errorf("histogram total = %d, want %d", total, n)
+	}
+}
+
+func TestBucketHistogramInvalidCount(t *testing.T) {
+	for _, buckets := range []int{0, -1} {
+		t.Run(fmt.Sprint(buckets), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("BucketHistogram(nil, %d) did not panic", buckets)
+				}
+			}()
+			BucketHistogram(nil, buckets)
+		})
+	}
+}
+
+func TestCollisionRate(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facades := randomFacades(t, 2000, key)
+
+	// About 2 of 2^20 buckets are expected to hold two facades.
+	if rate := CollisionRate(facades, 1<<20); rate > 1e-4 {
+		t.Errorf("CollisionRate over 2^20 buckets = %g, want < 1e-4", rate)
+	}
+
+	// With far fewer buckets than facades, every bucket collides.
+	if rate := CollisionRate(facades, 16); rate != 1 {
+		t.Errorf("CollisionRate over 16 buckets = %g, want 1", rate)
+	}
+
+	if rate := CollisionRate(nil, 8); rate != 0 {
+		t.Errorf("CollisionRate(nil) = %g, want 0", rate)
+	}
+}
+
+func TestHash64(t *testing.T) {
+	u := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if u.Hash64() != u.Hash64() {
+		t.Error("Hash64 is not deterministic")
+	}
+
+	other := u
+	other[0] ^= 1
+	if u.Hash64() == other.Hash64() {
+		t.Error("Hash64 ignores the timestamp bytes")
+	}
+
+	const (
+		n       = 16000
+		buckets = 16
+	)
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	var counts [buckets]int
+	for _, f := range randomFacades(t, n, key) {
+		counts[f.Hash64()%buckets]++
+	}
+	mean := n / buckets
+	for i, c := range counts {
+		if c < mean*8/10 || c > mean*12/10 {
+			t.Errorf("bucket %d has %d hashes, want about %d", i, c, mean)
+		}
+	}
+}
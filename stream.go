@@ -0,0 +1,63 @@
+package uuid47
+
+import "io"
+
+// encodingWriter implements the writer returned by EncodingWriter.
+type encodingWriter struct {
+	w       io.Writer
+	key     Key
+	pending UUID
+	n       int  // bytes buffered in pending
+	out     UUID // facade being forwarded; kept here so Write does not allocate
+}
+
+// EncodingWriter returns a writer that treats its input as a stream of raw
+// 16-byte UUIDs, encoding each one under key before forwarding it to w.
+// Writes need not be aligned to 16 bytes: a partial UUID is held until later
+// writes complete it, and a trailing partial UUID is never forwarded.
+func EncodingWriter(w io.Writer, key Key) io.Writer {
+	return &encodingWriter{w: w, key: key}
+}
+
+// Write implements io.Writer. Each complete UUID is forwarded to w as a
+// separate 16-byte write. On a downstream error, Write returns the number of
+// bytes of p consumed before the failing UUID and leaves its buffered state as
+// it was before that UUID, so retrying with the rest of p resumes the stream.
+// If w itself wrote part of a facade before failing, those bytes are already
+// downstream and will be sent again on retry.
+func (e *encodingWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		pending := e.pending
+		k := copy(pending[e.n:], p)
+		if e.n+k < 16 {
+			e.pending = pending
+			e.n += k
+			return written + k, nil
+		}
+		e.out = Encode(pending, e.key)
+		if _, err := e.w.Write(e.out[:]); err != nil {
+			return written, err
+		}
+		e.n = 0
+		p = p[k:]
+		written += k
+	}
+	return written, nil
+}
+
+// EncodeChan starts a pipeline stage that encodes each UUID received from in
+// under key and sends the facade on the returned channel, preserving order.
+// The output channel is unbuffered and is closed once in is closed and
+// drained. The caller must keep receiving until then, or the stage's
+// goroutine will block forever.
+func EncodeChan(in <-chan UUID, key Key) <-chan UUID {
+	out := make(chan UUID)
+	go func() {
+		defer close(out)
+		for u := range in {
+			out <- Encode(u, key)
+		}
+	}()
+	return out
+}
@@ -0,0 +1,155 @@
+package uuid47
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// failingWriter writes to buf until fail is set, then rejects every write.
+type failingWriter struct {
+	buf  bytes.Buffer
+	fail bool
+}
+
+var errDownstream = errors.New("downstream failure")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		return 0, errDownstream
+	}
+	return w.buf.Write(p)
+}
+
+func TestEncodingWriter(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7s := []UUID{
+		NewV7FromParts(1, 0x001, 0x001),
+		NewV7FromParts(2, 0x002, 0x002),
+		NewV7FromParts(3, 0x003, 0x003),
+	}
+
+	var input, want bytes.Buffer
+	for _, u := range v7s {
+		f := Encode(u, key)
+		input.Write(u[:])
+		want.Write(f[:])
+	}
+	input.Write([]byte{0xAA, 0xBB}) // trailing partial UUID
+
+	var got bytes.Buffer
+	w := EncodingWriter(&got, key)
+	data := input.Bytes()
+	for _, size := range []int{5, 1, 20, 7, 3, 14} {
+		n, err := w.Write(data[:size])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != size {
+			t.Errorf("Write returned %d, want %d", n, size)
+		}
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		t.Fatalf("test chunks left %d bytes unwritten", len(data))
+	}
+
+	if !bytes.Equal(got.Bytes(), want.Bytes()) {
+		t.Errorf("downstream bytes:\ngot  %x\nwant %x", got.Bytes(), want.Bytes())
+	}
+}
+
+func TestEncodingWriterRetry(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7s := []UUID{
+		NewV7FromParts(1, 0x001, 0x001),
+		NewV7FromParts(2, 0x002, 0x002),
+		NewV7FromParts(3, 0x003, 0x003),
+	}
+
+	var input, want bytes.Buffer
+	for _, u := range v7s {
+		f := Encode(u, key)
+		input.Write(u[:])
+		want.Write(f[:])
+	}
+	data := input.Bytes()
+
+	fw := &failingWriter{}
+	w := EncodingWriter(fw, key)
+
+	// Buffer half a UUID, then fail while flushing the first one.
+	if n, err := w.Write(data[:8]); n != 8 || err != nil {
+		t.Fatalf("Write(8 bytes) = %d, %v", n, err)
+	}
+	fw.fail = true
+	n, err := w.Write(data[8:40])
+	if !errors.Is(err, errDownstream) {
+		t.Fatalf("Write error = %v, want errDownstream", err)
+	}
+	if n != 0 {
+		t.Errorf("failed Write returned %d, want 0", n)
+	}
+
+	// Fail again after the first UUID has been forwarded.
+	fw.fail = false
+	if n, err := w.Write(data[8:16]); n != 8 || err != nil {
+		t.Fatalf("Write(8 bytes) = %d, %v", n, err)
+	}
+	fw.fail = true
+	n, err = w.Write(data[16:40])
+	if !errors.Is(err, errDownstream) || n != 0 {
+		t.Fatalf("Write = %d, %v, want 0, errDownstream", n, err)
+	}
+
+	// Retrying with the unconsumed bytes resumes the stream exactly.
+	fw.fail = false
+	rest := data[16:]
+	for len(rest) > 0 {
+		n, err := w.Write(rest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rest = rest[n:]
+	}
+	if !bytes.Equal(fw.buf.Bytes(), want.Bytes()) {
+		t.Errorf("downstream bytes after retry:\ngot  %x\nwant %x", fw.buf.Bytes(), want.Bytes())
+	}
+
+	w = EncodingWriter(io.Discard, key)
+	if a := testing.AllocsPerRun(100, func() { _, _ = w.Write(data) }); a != 0 {
+		t.Errorf("Write allocated %v times, want 0", a)
+	}
+}
+
+func TestEncodeChan(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7s := []UUID{
+		NewV7FromParts(1, 0x001, 0x001),
+		NewV7FromParts(2, 0x002, 0x002),
+		NewV7FromParts(3, 0x003, 0x003),
+	}
+
+	in := make(chan UUID)
+	go func() {
+		defer close(in)
+		for _, u := range v7s {
+			in <- u
+		}
+	}()
+
+	var got []UUID
+	for f := range EncodeChan(in, key) {
+		got = append(got, f)
+	}
+
+	if len(got) != len(v7s) {
+		t.Fatalf("got %d facades, want %d", len(got), len(v7s))
+	}
+	for i, u := range v7s {
+		if got[i] != Encode(u, key) {
+			t.Errorf("facade %d = %s, want %s", i, got[i], Encode(u, key))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package uuid47
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+
+	"github.com/dchest/siphash"
+)
+
+// ErrBadSignature is returned by ParseSignedToken when a token's tag does not
+// match its facade.
+var ErrBadSignature = errors.New("invalid token signature")
+
+// signedTokenLen is the byte length of a decoded signed token: facade || tag.
+const signedTokenLen = 16 + 4
+
+// FacadeTag computes a 32-bit integrity tag over a facade using a separate
+// verification key. The tag is meant to travel out-of-band alongside the
+// facade so that a service holding only verifyKey can detect corruption or
+// tampering without being able to decode the facade.
+func FacadeTag(facade UUID, verifyKey Key) uint32 {
+	return uint32(siphash.Hash(verifyKey.K0, verifyKey.K1, facade[:]))
+}
+
+// VerifyFacadeTag reports whether tag matches the facade under verifyKey.
+// The comparison runs in constant time.
+func VerifyFacadeTag(facade UUID, tag uint32, verifyKey Key) bool {
+	var want, got [4]byte
+	binary.BigEndian.PutUint32(want[:], FacadeTag(facade, verifyKey))
+	binary.BigEndian.PutUint32(got[:], tag)
+	return subtle.ConstantTimeCompare(want[:], got[:]) == 1
+}
+
+// SignedToken returns a compact tamper-evident token for a facade: the
+// unpadded base64url encoding of the 16 facade bytes followed by the 4-byte
+// big-endian FacadeTag under signKey. Tokens are 27 characters long.
+func SignedToken(facade UUID, signKey Key) string {
+	var buf [signedTokenLen]byte
+	copy(buf[:16], facade[:])
+	binary.BigEndian.PutUint32(buf[16:], FacadeTag(facade, signKey))
+	return base64.RawURLEncoding.EncodeToString(buf[:])
+}
+
+// ParseSignedToken validates a token produced by SignedToken and returns its
+// facade. It returns ErrInvalidUUID if the token is malformed and
+// ErrBadSignature if the tag does not verify under signKey.
+func ParseSignedToken(token string, signKey Key) (UUID, error) {
+	var buf [signedTokenLen]byte
+	if base64.RawURLEncoding.EncodedLen(signedTokenLen) != len(token) {
+		return UUID{}, ErrInvalidUUID
+	}
+	if _, err := base64.RawURLEncoding.Strict().Decode(buf[:], []byte(token)); err != nil {
+		return UUID{}, ErrInvalidUUID
+	}
+	facade := UUID(buf[:16])
+	if !VerifyFacadeTag(facade, binary.BigEndian.Uint32(buf[16:]), signKey) {
+		return UUID{}, ErrBadSignature
+	}
+	return facade, nil
+}
+
+// GenerateSignedFacade runs the full "create a public ID" flow: it generates a
+// new v7 to store, encodes it under encKey, and signs the facade under signKey.
+// The returned token is what clients see; ParseSignedToken followed by Decode
+// recovers stored.
+func GenerateSignedFacade(encKey, signKey Key) (stored UUID, token string, err error) {
+	stored, err = NewV7()
+	if err != nil {
+		return UUID{}, "", err
+	}
+	return stored, SignedToken(Encode(stored, encKey), signKey), nil
+}
@@ -0,0 +1,101 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFacadeTag(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	verifyKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+
+	v7, err := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	facade := Encode(v7, key)
+	tag := FacadeTag(facade, verifyKey)
+
+	if !VerifyFacadeTag(facade, tag, verifyKey) {
+		t.Error("valid tag failed verification")
+	}
+
+	if FacadeTag(facade, verifyKey) != tag {
+		t.Error("FacadeTag is not deterministic")
+	}
+
+	wrongKey := Key{K0: verifyKey.K0 ^ 1, K1: verifyKey.K1}
+	if VerifyFacadeTag(facade, tag, wrongKey) {
+		t.Error("tag verified under the wrong verification key")
+	}
+
+	// Flipping any single bit of the facade should invalidate the tag.
+	for bit := range 128 {
+		tampered := facade
+		tampered[bit/8] ^= 1 << (bit % 8)
+		if VerifyFacadeTag(tampered, tag, verifyKey) {
+			t.Errorf("tag verified for facade with bit %d flipped", bit)
+		}
+	}
+}
+
+func TestSignedToken(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	signKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+	facade := Encode(mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"), key)
+
+	token := SignedToken(facade, signKey)
+	if len(token) != 27 {
+		t.Errorf("token length = %d, want 27", len(token))
+	}
+
+	got, err := ParseSignedToken(token, signKey)
+	if err != nil {
+		t.Fatalf("ParseSignedToken failed: %v", err)
+	}
+	if got != facade {
+		t.Errorf("ParseSignedToken = %s, want %s", got, facade)
+	}
+
+	// Change one character in the facade portion of the token.
+	tampered := []byte(token)
+	if tampered[3] == 'A' {
+		tampered[3] = 'B'
+	} else {
+		tampered[3] = 'A'
+	}
+	if _, err := ParseSignedToken(string(tampered), signKey); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("tampered token error = %v, want ErrBadSignature", err)
+	}
+
+	if _, err := ParseSignedToken(token, key); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("wrong key error = %v, want ErrBadSignature", err)
+	}
+
+	for _, bad := range []string{"", token[:26], token + "A", "!!!!!!!!!!!!!!!!!!!!!!!!!!!"} {
+		if _, err := ParseSignedToken(bad, signKey); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseSignedToken(%q) error = %v, want ErrInvalidUUID", bad, err)
+		}
+	}
+}
+
+func TestGenerateSignedFacade(t *testing.T) {
+	encKey := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	signKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+
+	stored, token, err := GenerateSignedFacade(encKey, signKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version() != 7 {
+		t.Errorf("stored version = %d, want 7", stored.Version())
+	}
+
+	facade, err := ParseSignedToken(token, signKey)
+	if err != nil {
+		t.Fatalf("token does not validate: %v", err)
+	}
+	if Decode(facade, encKey) != stored {
+		t.Errorf("token decodes to %s, want %s", Decode(facade, encKey), stored)
+	}
+}
@@ -0,0 +1,52 @@
+package uuid47
+
+import "errors"
+
+// KeyIDBits is the number of rand_a bits EncodeTagged sacrifices to carry a
+// key ID, allowing up to 1<<KeyIDBits keys.
+const KeyIDBits = 2
+
+// keyIDMask selects the two most significant rand_a bits in byte 6.
+const keyIDMask = 0x0C
+
+// ErrUnknownKeyID is returned when a key ID is out of range or has no
+// corresponding entry in the supplied key set.
+var ErrUnknownKeyID = errors.New("unknown key ID")
+
+// TagKeyID returns u with the key ID stored in the two most significant bits
+// of rand_a. Only the low KeyIDBits bits of id are used.
+func TagKeyID(u UUID, id uint8) UUID {
+	u[6] = (u[6] &^ keyIDMask) | ((id << 2) & keyIDMask)
+	return u
+}
+
+// KeyID returns the key ID stored by TagKeyID. Encode leaves rand_a intact,
+// so this reads the same value from a tagged v7 and from its facade.
+func (u UUID) KeyID() uint8 {
+	return (u[6] & keyIDMask) >> 2
+}
+
+// EncodeTagged stamps keyID into uuid with TagKeyID and encodes the result
+// under keys[keyID], producing a facade that identifies its own key.
+//
+// The key ID replaces two random bits, so a tagged v7 keeps 72 rather than 74
+// bits of randomness, and those two bits of the original v7 are lost:
+// DecodeTagged returns TagKeyID(uuid, keyID), which is the value that should
+// be stored. Generating v7s with the ID already in place avoids the
+// difference entirely.
+func EncodeTagged(uuid UUID, keyID uint8, keys []Key) (UUID, error) {
+	if int(keyID) >= len(keys) || keyID >= 1<<KeyIDBits {
+		return UUID{}, ErrUnknownKeyID
+	}
+	return Encode(TagKeyID(uuid, keyID), keys[keyID]), nil
+}
+
+// DecodeTagged reads the key ID embedded by EncodeTagged, decodes the facade
+// under keys[id], and returns the tagged v7 together with the ID.
+func DecodeTagged(facade UUID, keys []Key) (UUID, uint8, error) {
+	id := facade.KeyID()
+	if int(id) >= len(keys) {
+		return UUID{}, id, ErrUnknownKeyID
+	}
+	return Decode(facade, keys[id]), id, nil
+}
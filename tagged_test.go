@@ -0,0 +1,57 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodeDecodeTagged(t *testing.T) {
+	keys := []Key{
+		{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210},
+		{K0: 0x1111111111111111, K1: 0x2222222222222222},
+		{K0: 0x3333333333333333, K1: 0x4444444444444444},
+		{K0: 0x5555555555555555, K1: 0x6666666666666666},
+	}
+	u7 := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+
+	for id := range uint8(4) {
+		facade, err := EncodeTagged(u7, id, keys)
+		if err != nil {
+			t.Fatalf("EncodeTagged(id=%d) failed: %v", id, err)
+		}
+		if !IsPossibleFacade(facade) {
+			t.Errorf("id=%d: facade %s is not a well-formed v4", id, facade)
+		}
+		if facade.KeyID() != id {
+			t.Errorf("id=%d: facade carries key ID %d", id, facade.KeyID())
+		}
+
+		back, gotID, err := DecodeTagged(facade, keys)
+		if err != nil {
+			t.Fatalf("DecodeTagged(id=%d) failed: %v", id, err)
+		}
+		if gotID != id {
+			t.Errorf("DecodeTagged returned key ID %d, want %d", gotID, id)
+		}
+		if want := TagKeyID(u7, id); back != want {
+			t.Errorf("id=%d: decoded %s, want %s", id, back, want)
+		}
+		if rd48be(back[:6]) != rd48be(u7[:6]) {
+			t.Errorf("id=%d: timestamp not preserved", id)
+		}
+	}
+}
+
+func TestEncodeTaggedUnknownKeyID(t *testing.T) {
+	keys := []Key{{K0: 1, K1: 2}, {K0: 3, K1: 4}}
+	u7 := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+
+	if _, err := EncodeTagged(u7, 2, keys); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("EncodeTagged error = %v, want ErrUnknownKeyID", err)
+	}
+
+	facade := Encode(TagKeyID(u7, 3), keys[0])
+	if _, _, err := DecodeTagged(facade, keys); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("DecodeTagged error = %v, want ErrUnknownKeyID", err)
+	}
+}
@@ -0,0 +1,37 @@
+package uuid47
+
+// FlipTimestampBits returns the 48 UUIDs that differ from base in exactly one
+// bit of the 48-bit timestamp field. Element i has timestamp bit i flipped,
+// counting from the least significant bit. All other bits, including version
+// and variant, are copied from base unchanged.
+//
+// It is intended for differential testing of the mask, such as avalanche and
+// injectivity checks over Encode.
+func FlipTimestampBits(base UUID) []UUID {
+	out := make([]UUID, 48)
+	ts48 := rd48be(base[:6])
+	for i := range out {
+		u := base
+		wr48be(u[:6], ts48^(1<<i))
+		out[i] = u
+	}
+	return out
+}
+
+// Craft builds a UUID with an arbitrary version and variant around the given
+// payload, for feeding validators combinations that no generator produces.
+//
+// The payload fills bytes 0-5, 7, and 9-15 in order. Byte 6 holds version in
+// its high nibble, and byte 8 holds variant in the same in-place form that
+// VariantBits returns (0x00 NCS, 0x80 RFC 4122, 0xC0 Microsoft, 0xE0
+// reserved). The low nibble of byte 6 and the low five bits of byte 8 are
+// zero.
+func Craft(version, variant byte, payload [14]byte) UUID {
+	var u UUID
+	copy(u[0:6], payload[0:6])
+	u[6] = (version & 0x0F) << 4
+	u[7] = payload[6]
+	u[8] = variant & 0xE0
+	copy(u[9:16], payload[7:14])
+	return u
+}
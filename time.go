@@ -0,0 +1,261 @@
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// ErrExpired is returned by DecodeFresh when a facade's embedded creation
+// time is older than the allowed TTL.
+var ErrExpired = errors.New("UUID has expired")
+
+// ErrImplausibleTimestamp is returned by DecodeStrict when the recovered
+// creation time falls outside the accepted window, which usually means the
+// facade was decoded with the wrong key.
+var ErrImplausibleTimestamp = errors.New("decoded timestamp is implausible")
+
+// Time returns the creation time embedded in a v7, in UTC at millisecond
+// resolution. ok is false, and the time zero, if u is not an RFC 4122 v7;
+// decode a facade before calling Time.
+func (u UUID) Time() (t time.Time, ok bool) {
+	if !isV7(u) {
+		return time.Time{}, false
+	}
+	return unixMilliTime(u), true
+}
+
+// TimeFromFacade returns the creation time of the v7 behind facade, in UTC,
+// without building the decoded UUID: it computes only the mask and unmasks
+// the timestamp. It is the cheap path for analytics that need event times
+// from API-facing IDs. It returns ErrNotFacade if facade is not a v4 with the
+// RFC 4122 variant; with the wrong key it returns a meaningless time.
+func TimeFromFacade(facade UUID, key Key) (time.Time, error) {
+	if !IsPossibleFacade(facade) {
+		return time.Time{}, ErrNotFacade
+	}
+	ms := rd48be(facade[:6]) ^ mask48(facade, key)
+	return time.UnixMilli(int64(ms)).UTC(), nil //nolint:gosec // G115: 48-bit value fits in int64
+}
+
+// TimePrefix8 returns the first 8 bytes of a UUIDv7: the 48-bit big-endian
+// millisecond timestamp followed by the version nibble and the 12-bit rand_a
+// field. It is intended as a fixed-width, time-ordered key prefix for
+// LSM-tree stores.
+//
+// Prefixes compare bytewise in timestamp order across milliseconds. Within a
+// single millisecond the order follows rand_a, which is random unless the
+// generator uses it as a counter. The result is only meaningful for v7s;
+// a facade's prefix is effectively random.
+func (u UUID) TimePrefix8() [8]byte {
+	return [8]byte(u[:8])
+}
+
+// Sub returns the difference between the creation times embedded in u and
+// other (u - other) at millisecond resolution. It is meaningful only when
+// both are v7s; for other versions it operates on the raw first 48 bits
+// regardless of what they contain.
+func (u UUID) Sub(other UUID) time.Duration {
+	ms := int64(rd48be(u[:6])) - int64(rd48be(other[:6])) //nolint:gosec // G115: 48-bit values fit in int64
+	return time.Duration(ms) * time.Millisecond
+}
+
+// TimeSkeleton returns a copy of u with its 74 random bits (rand_a and rand_b)
+// cleared, leaving the timestamp, version, and variant bits intact. For a v7
+// this is the smallest UUID with the same millisecond and version.
+func (u UUID) TimeSkeleton() UUID {
+	u[6] &= 0xF0
+	u[7] = 0
+	u[8] &= 0xC0
+	clear(u[9:])
+	return u
+}
+
+// IsFuture reports whether the creation time embedded in a v7 is after now.
+// It is shorthand for IsFutureBy(now, 0).
+func (u UUID) IsFuture(now time.Time) bool {
+	return u.IsFutureBy(now, 0)
+}
+
+// IsFutureBy reports whether the creation time embedded in a v7 is more than
+// tolerance after now, allowing for small clock skew between producers and
+// the validating service. Only the first 48 bits are inspected, so the result
+// is meaningless for non-v7 input.
+func (u UUID) IsFutureBy(now time.Time, tolerance time.Duration) bool {
+	return unixMilliTime(u).After(now.Add(tolerance))
+}
+
+// TimeBucket returns the creation time embedded in a v7, in UTC, rounded down
+// to a multiple of interval since the zero time (see time.Time.Truncate).
+// An interval <= 0 returns the time unchanged.
+func (u UUID) TimeBucket(interval time.Duration) time.Time {
+	return unixMilliTime(u).Truncate(interval)
+}
+
+// IsAlignedTo reports whether the creation time embedded in a v7 is an exact
+// multiple of d since the Unix epoch. In deployments whose clocks only tick
+// in whole seconds, for example, a decoded ID that is not aligned to
+// time.Second points to the wrong key or a corrupted value. Like TimeBucket,
+// an interval d <= 0 leaves every time unchanged and so reports true.
+func (u UUID) IsAlignedTo(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	// 2^48 ms in nanoseconds overflows int64, so take the remainder of the
+	// full 128-bit product.
+	hi, lo := bits.Mul64(rd48be(u[:6]), uint64(time.Millisecond))
+	return bits.Rem64(hi, lo, uint64(d)) == 0 //nolint:gosec // G115: d > 0 checked above
+}
+
+// Age returns how long before now the v7 was created, at millisecond
+// resolution. A creation time after now yields a negative duration. Only the
+// first 48 bits are inspected, so decode a facade before calling Age.
+func (u UUID) Age(now time.Time) time.Duration {
+	return now.Sub(unixMilliTime(u))
+}
+
+// AgeString returns Age as a coarse string for display, such as "3h ago",
+// using the largest whole unit of days, hours, minutes, or seconds. Ages under
+// a second in either direction are "just now", and future creation times read
+// as "in 5m".
+func (u UUID) AgeString(now time.Time) string {
+	age := u.Age(now)
+	suffix, prefix := " ago", ""
+	if age < 0 {
+		age, suffix, prefix = -age, "", "in "
+	}
+
+	var n time.Duration
+	var unit string
+	switch {
+	case age < time.Second:
+		return "just now"
+	case age < time.Minute:
+		n, unit = age/time.Second, "s"
+	case age < time.Hour:
+		n, unit = age/time.Minute, "m"
+	case age < 24*time.Hour:
+		n, unit = age/time.Hour, "h"
+	default:
+		n, unit = age/(24*time.Hour), "d"
+	}
+	return prefix + strconv.FormatInt(int64(n), 10) + unit + suffix
+}
+
+// DecodeFresh decodes facade under key and returns ErrExpired if the recovered
+// creation time is more than ttl before now. This turns the timestamp into a
+// lightweight expiry for short-lived IDs. Future-dated IDs are not rejected;
+// combine with IsFutureBy if that matters.
+func DecodeFresh(facade UUID, key Key, ttl time.Duration, now time.Time) (UUID, error) {
+	u := Decode(facade, key)
+	if now.Sub(unixMilliTime(u)) > ttl {
+		return UUID{}, ErrExpired
+	}
+	return u, nil
+}
+
+// Window bounds the creation times that DecodeStrict treats as plausible.
+type Window struct {
+	// NotBefore is the earliest plausible creation time, typically when the
+	// system first issued v7s.
+	NotBefore time.Time
+
+	// Tolerance is how far after now a creation time may be, covering clock
+	// skew between the producer and the decoder.
+	Tolerance time.Duration
+}
+
+// DefaultWindow accepts creation times from the start of 2015 to one minute
+// after now.
+var DefaultWindow = Window{
+	NotBefore: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+	Tolerance: time.Minute,
+}
+
+// check returns an error wrapping ErrImplausibleTimestamp if the creation
+// time embedded in u is outside w, given the current time now.
+func (w Window) check(u UUID, now time.Time) error {
+	t := unixMilliTime(u)
+	if t.Before(w.NotBefore) || t.After(now.Add(w.Tolerance)) {
+		return fmt.Errorf("%w: %s", ErrImplausibleTimestamp, t.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// DecodeStrict decodes facade under key and checks that the result is
+// believable. It returns ErrNotFacade if facade is not a v4 with the RFC 4122
+// variant, and an error wrapping ErrImplausibleTimestamp if the recovered
+// creation time is outside w relative to now.
+//
+// Decoding with the wrong key yields a well-formed v7 with an effectively
+// random timestamp, so this detects most key mismatches at the boundary. It
+// cannot detect all of them: a random timestamp lands inside the window with
+// probability equal to the window's width over the 2^48 ms range, about one in
+// 750 for DefaultWindow in the mid-2020s.
+func DecodeStrict(facade UUID, key Key, w Window, now time.Time) (UUID, error) {
+	if !IsPossibleFacade(facade) {
+		return UUID{}, ErrNotFacade
+	}
+	u := Decode(facade, key)
+	if err := w.check(u, now); err != nil {
+		return UUID{}, err
+	}
+	return u, nil
+}
+
+// MinForTime returns the smallest v7 that can be created in the millisecond
+// containing t: its timestamp with every random bit zero. Together with
+// MaxForTime it bounds a range query over a v7-keyed table:
+//
+//	WHERE id BETWEEN MinForTime(start) AND MaxForTime(end)
+//
+// selects the IDs created from start's millisecond through end's, inclusive.
+// The bounds compare correctly only against v7s, not facades, and t must lie
+// between the Unix epoch and MaxTimestamp.
+func MinForTime(t time.Time) UUID {
+	return V7Builder{}.Time(t).Build()
+}
+
+// MaxForTime returns the largest v7 that can be created in the millisecond
+// containing t: its timestamp with every random bit set. See MinForTime.
+func MaxForTime(t time.Time) UUID {
+	return V7Builder{}.Time(t).RandA(0x0FFF).RandB(1<<62 - 1).Build()
+}
+
+// maxTimestamp48 is the largest value of the 48-bit millisecond field.
+const maxTimestamp48 = 1<<48 - 1
+
+// MaxTimestamp returns the latest creation time a v7 can represent: 2^48-1
+// milliseconds after the Unix epoch, in August of the year 10889 (UTC).
+func MaxTimestamp() time.Time {
+	return time.UnixMilli(maxTimestamp48).UTC()
+}
+
+// TimestampWillOverflow reports whether t is too late to fit in the 48-bit
+// v7 timestamp field, that is, whether it is after MaxTimestamp. Times are
+// compared at millisecond resolution, as they would be stored.
+func TimestampWillOverflow(t time.Time) bool {
+	return t.UnixMilli() > maxTimestamp48
+}
+
+// GroupByDay partitions uuids by the UTC calendar date (YYYY-MM-DD) of their
+// embedded creation time, preserving input order within each group. Entries
+// that are not v7s are collected under the empty key "".
+func GroupByDay(uuids []UUID) map[string][]UUID {
+	groups := make(map[string][]UUID)
+	for _, u := range uuids {
+		day := ""
+		if isV7(u) {
+			day = unixMilliTime(u).Format(time.DateOnly)
+		}
+		groups[day] = append(groups[day], u)
+	}
+	return groups
+}
+
+// unixMilliTime interprets the first 48 bits of u as Unix milliseconds.
+func unixMilliTime(u UUID) time.Time {
+	return time.UnixMilli(int64(rd48be(u[:6]))).UTC() //nolint:gosec // G115: 48-bit value fits in int64
+}
@@ -0,0 +1,364 @@
+package uuid47
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	got, ok := v7.Time()
+	want := time.Date(2024, 4, 30, 6, 9, 45, 514_000_000, time.UTC)
+	if !ok || !got.Equal(want) {
+		t.Errorf("Time = %v, %v; want %v, true", got, ok, want)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("Time location = %v, want UTC", got.Location())
+	}
+
+	for _, u := range []UUID{Encode(v7, key), {}, mustParse(t, "018f2d9f-9a2a-7def-cc3f-7b1a2c4d5e6f")} {
+		if got, ok := u.Time(); ok || !got.IsZero() {
+			t.Errorf("%s.Time() = %v, %v; want zero, false", u, got, ok)
+		}
+	}
+}
+
+func TestTimeFromFacade(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := mustParse(t, "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f")
+
+	got, err := TimeFromFacade(facade, key)
+	if err != nil {
+		t.Fatalf("TimeFromFacade failed: %v", err)
+	}
+	want, _ := Decode(facade, key).Time()
+	if !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("TimeFromFacade = %v, want %v", got, want)
+	}
+
+	v7 := Decode(facade, key)
+	if _, err := TimeFromFacade(v7, key); !errors.Is(err, ErrNotFacade) {
+		t.Errorf("TimeFromFacade(v7) error = %v, want ErrNotFacade", err)
+	}
+}
+
+func TestTimePrefix8(t *testing.T) {
+	u := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	p := u.TimePrefix8()
+	if !bytes.Equal(p[:], u[:8]) {
+		t.Errorf("TimePrefix8 = %x, want %x", p, u[:8])
+	}
+
+	// Increasing timestamps with decreasing random bits must still sort by time.
+	var prev [8]byte
+	for i := range uint64(32) {
+		ts := 0x018f2d9f0000 + i*977
+		ra := uint16(0x0FFF - i) //nolint:gosec // G115: Safe conversion in test with i < 32
+		rb := (uint64(1) << 62) - 1 - i
+		cur := NewV7FromParts(ts, ra, rb).TimePrefix8()
+		if i > 0 && bytes.Compare(prev[:], cur[:]) >= 0 {
+			t.Errorf("prefix %d (%x) does not sort after %x", i, cur, prev)
+		}
+		prev = cur
+	}
+}
+
+func TestSub(t *testing.T) {
+	a := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	b := NewV7FromParts(0x018f2d9f9a2a+1000, 0x0123, 0x0456)
+
+	if d := b.Sub(a); d != time.Second {
+		t.Errorf("b.Sub(a) = %v, want 1s", d)
+	}
+	if d := a.Sub(b); d != -time.Second {
+		t.Errorf("a.Sub(b) = %v, want -1s", d)
+	}
+	if d := a.Sub(a); d != 0 {
+		t.Errorf("a.Sub(a) = %v, want 0", d)
+	}
+}
+
+func TestTimeSkeleton(t *testing.T) {
+	u := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	sk := u.TimeSkeleton()
+
+	if msg := buildSipInputFromV7(sk); msg != [10]byte{} {
+		t.Errorf("random bits not cleared: %x", msg)
+	}
+	if rd48be(sk[:6]) != rd48be(u[:6]) {
+		t.Errorf("timestamp changed: got %012x, want %012x", rd48be(sk[:6]), rd48be(u[:6]))
+	}
+	if sk.Version() != 7 {
+		t.Errorf("Version should be 7, got %d", sk.Version())
+	}
+	if (sk[8] & 0xC0) != 0x80 {
+		t.Errorf("Variant bits incorrect: got %02x", sk[8])
+	}
+	if sk != NewV7FromParts(0x018f2d9f9a2a, 0, 0) {
+		t.Errorf("TimeSkeleton = %s, want %s", sk, NewV7FromParts(0x018f2d9f9a2a, 0, 0))
+	}
+}
+
+func TestIsFuture(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) UUID {
+		return NewV7FromParts(uint64(now.Add(d).UnixMilli()), 0x0def, 0x0c3f7b1a2c4d5e6f) //nolint:gosec // G115: post-epoch test times
+	}
+
+	tests := []struct {
+		name       string
+		u          UUID
+		wantFuture bool
+		wantBy     bool
+	}{
+		{"past", at(-time.Hour), false, false},
+		{"now", at(0), false, false},
+		{"within tolerance", at(2 * time.Second), true, false},
+		{"clearly future", at(time.Hour), true, true},
+	}
+
+	const tolerance = 5 * time.Second
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.u.IsFuture(now); got != tc.wantFuture {
+				t.Errorf("IsFuture = %v, want %v", got, tc.wantFuture)
+			}
+			if got := tc.u.IsFutureBy(now, tolerance); got != tc.wantBy {
+				t.Errorf("IsFutureBy(%v) = %v, want %v", tolerance, got, tc.wantBy)
+			}
+		})
+	}
+}
+
+func TestTimeBucket(t *testing.T) {
+	created := time.Date(2025, 3, 14, 12, 34, 56, 789e6, time.UTC)
+	u := NewV7FromParts(uint64(created.UnixMilli()), 0x0def, 0x0c3f7b1a2c4d5e6f) //nolint:gosec // G115: post-epoch test time
+
+	tests := []struct {
+		interval time.Duration
+		want     time.Time
+	}{
+		{time.Hour, time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)},
+		{15 * time.Minute, time.Date(2025, 3, 14, 12, 30, 0, 0, time.UTC)},
+		{time.Second, time.Date(2025, 3, 14, 12, 34, 56, 0, time.UTC)},
+		{0, created},
+	}
+
+	for _, tc := range tests {
+		got := u.TimeBucket(tc.interval)
+		if !got.Equal(tc.want) || got.Location() != time.UTC {
+			t.Errorf("TimeBucket(%v) = %v, want %v", tc.interval, got, tc.want)
+		}
+	}
+}
+
+func TestDecodeFresh(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	ms := uint64(now.Add(-30 * time.Second).UnixMilli()) //nolint:gosec // G115: post-epoch test time
+	u7 := NewV7FromParts(ms, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	facade := Encode(u7, key)
+
+	got, err := DecodeFresh(facade, key, time.Minute, now)
+	if err != nil {
+		t.Fatalf("DecodeFresh failed for fresh facade: %v", err)
+	}
+	if got != u7 {
+		t.Errorf("DecodeFresh = %s, want %s", got, u7)
+	}
+
+	if _, err := DecodeFresh(facade, key, 10*time.Second, now); !errors.Is(err, ErrExpired) {
+		t.Errorf("DecodeFresh error = %v, want ErrExpired", err)
+	}
+}
+
+func TestAge(t *testing.T) {
+	u := NewV7FromParts(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	created := time.UnixMilli(0x018f2d9f9a2a)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		want    time.Duration
+		wantStr string
+	}{
+		{"same instant", created, 0, "just now"},
+		{"sub-second", created.Add(999 * time.Millisecond), 999 * time.Millisecond, "just now"},
+		{"seconds", created.Add(42 * time.Second), 42 * time.Second, "42s ago"},
+		{"minutes", created.Add(5*time.Minute + 59*time.Second), 5*time.Minute + 59*time.Second, "5m ago"},
+		{"hours", created.Add(3 * time.Hour), 3 * time.Hour, "3h ago"},
+		{"days", created.Add(50 * time.Hour), 50 * time.Hour, "2d ago"},
+		{"future", created.Add(-5 * time.Minute), -5 * time.Minute, "in 5m"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := u.Age(tc.now); got != tc.want {
+				t.Errorf("Age = %v, want %v", got, tc.want)
+			}
+			if got := u.AgeString(tc.now); got != tc.wantStr {
+				t.Errorf("AgeString = %q, want %q", got, tc.wantStr)
+			}
+		})
+	}
+}
+
+func TestIsAlignedTo(t *testing.T) {
+	const sec = 1714457385000 // 2024-04-30T06:09:45Z in ms
+	aligned := NewV7FromParts(sec, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	off := NewV7FromParts(sec+514, 0x0def, 0x0c3f7b1a2c4d5e6f)
+
+	tests := []struct {
+		name string
+		u    UUID
+		d    time.Duration
+		want bool
+	}{
+		{"second aligned to second", aligned, time.Second, true},
+		{"second aligned to 5s", aligned, 5 * time.Second, true},
+		{"second not aligned to minute", aligned, time.Minute, false},
+		{"millisecond off second", off, time.Second, false},
+		{"millisecond off aligned to 2ms", off, 2 * time.Millisecond, true},
+		{"any aligned to millisecond", off, time.Millisecond, true},
+		{"any aligned to microsecond", off, time.Microsecond, true},
+		{"max timestamp to second", NewV7FromParts(maxTimestamp48, 0, 0), time.Second, false},
+		{"zero interval", off, 0, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.u.IsAlignedTo(tc.d); got != tc.want {
+				t.Errorf("IsAlignedTo(%v) = %v, want %v", tc.d, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	wrongKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f") // 2024-04-30T06:09:45.514Z
+	facade := Encode(v7, key)
+	created := time.UnixMilli(0x018f2d9f9a2a)
+
+	got, err := DecodeStrict(facade, key, DefaultWindow, created.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("DecodeStrict failed: %v", err)
+	}
+	if got != v7 {
+		t.Errorf("DecodeStrict = %s, want %s", got, v7)
+	}
+
+	tests := []struct {
+		name    string
+		facade  UUID
+		key     Key
+		w       Window
+		now     time.Time
+		wantErr error
+	}{
+		{"not a facade", v7, key, DefaultWindow, created, ErrNotFacade},
+		{"wrong key", facade, wrongKey, DefaultWindow, created, ErrImplausibleTimestamp},
+		{"before window", facade, key, Window{NotBefore: created.Add(time.Millisecond)}, created, ErrImplausibleTimestamp},
+		{"beyond tolerance", facade, key, DefaultWindow, created.Add(-2 * time.Minute), ErrImplausibleTimestamp},
+		{"within tolerance", facade, key, DefaultWindow, created.Add(-time.Minute), nil},
+		{"at window start", facade, key, Window{NotBefore: created}, created, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeStrict(tc.facade, tc.key, tc.w, tc.now)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("DecodeStrict error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil && got != (UUID{}) {
+				t.Errorf("DecodeStrict returned %s alongside an error", got)
+			}
+		})
+	}
+}
+
+func TestMinMaxForTime(t *testing.T) {
+	created := time.Date(2024, 4, 30, 6, 9, 45, 514_300_000, time.UTC)
+
+	lo, hi := MinForTime(created), MaxForTime(created)
+	if lo.String() != "018f2d9f-9a2a-7000-8000-000000000000" {
+		t.Errorf("MinForTime = %s", lo)
+	}
+	if hi.String() != "018f2d9f-9a2a-7fff-bfff-ffffffffffff" {
+		t.Errorf("MaxForTime = %s", hi)
+	}
+
+	inside := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	before := MaxForTime(created.Add(-time.Millisecond))
+	after := MinForTime(created.Add(time.Millisecond))
+	if Compare(lo, inside) > 0 || Compare(inside, hi) > 0 {
+		t.Error("v7 from the same millisecond falls outside the bounds")
+	}
+	if Compare(before, lo) >= 0 || Compare(hi, after) >= 0 {
+		t.Error("bounds overlap adjacent milliseconds")
+	}
+	if lo != inside.TimeSkeleton() {
+		t.Errorf("MinForTime = %s, want TimeSkeleton %s", lo, inside.TimeSkeleton())
+	}
+}
+
+func TestMaxTimestamp(t *testing.T) {
+	maxTS := MaxTimestamp()
+	if maxTS.Year() != 10889 {
+		t.Errorf("MaxTimestamp year = %d, want 10889", maxTS.Year())
+	}
+	if maxTS.UnixMilli() != 1<<48-1 {
+		t.Errorf("MaxTimestamp = %d ms, want %d", maxTS.UnixMilli(), int64(1<<48-1))
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"now", time.Now(), false},
+		{"max", maxTS, false},
+		{"max plus sub-millisecond", maxTS.Add(999 * time.Microsecond), false},
+		{"max plus 1ms", maxTS.Add(time.Millisecond), true},
+		{"far future", time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := TimestampWillOverflow(tc.t); got != tc.want {
+				t.Errorf("TimestampWillOverflow(%v) = %v, want %v", tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupByDay(t *testing.T) {
+	day1 := time.Date(2024, 5, 1, 23, 59, 59, 0, time.UTC)
+	day2 := day1.Add(2 * time.Second)
+	ms := func(t time.Time) uint64 { return uint64(t.UnixMilli()) } //nolint:gosec // G115: post-epoch test times
+
+	a := NewV7FromParts(ms(day1), 0x001, 0x001)
+	b := NewV7FromParts(ms(day2), 0x002, 0x002)
+	c := NewV7FromParts(ms(day1)+500, 0x003, 0x003)
+	v5 := NewV5(NamespaceDNS, []byte("example.com"))
+
+	groups := GroupByDay([]UUID{a, b, v5, c})
+
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3: %v", len(groups), groups)
+	}
+	if got := groups["2024-05-01"]; len(got) != 2 || got[0] != a || got[1] != c {
+		t.Errorf("2024-05-01 group = %v, want [%s %s]", got, a, c)
+	}
+	if got := groups["2024-05-02"]; len(got) != 1 || got[0] != b {
+		t.Errorf("2024-05-02 group = %v, want [%s]", got, b)
+	}
+	if got := groups[""]; len(got) != 1 || got[0] != v5 {
+		t.Errorf("non-v7 group = %v, want [%s]", got, v5)
+	}
+}
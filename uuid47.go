@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"errors"
+	"strconv"
 
 	"github.com/dchest/siphash"
 )
@@ -15,6 +16,23 @@ import (
 // UUID represents a 128-bit UUID.
 type UUID [16]byte
 
+// Special UUIDs defined by RFC 9562 section 5.9 and 5.10.
+//
+// Neither is a v7 and Encode does not special-case them: like any other input
+// their first 48 bits are masked and their variant is rewritten, so
+// Encode(Nil) is an ordinary-looking facade and Decode(Encode(Nil)) is not
+// Nil. EncodeStrict rejects both with ErrNonRFCVariant. To carry "no ID"
+// across the API boundary, check IsNil before encoding and pass Nil through
+// as is.
+var (
+	// Nil is the nil UUID, with all 128 bits zero. It is the zero value of
+	// UUID.
+	Nil UUID
+
+	// Max is the max UUID, with all 128 bits set, for use as an upper bound.
+	Max = UUID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+)
+
 // Key represents a 128-bit SipHash key.
 type Key struct {
 	K0, K1 uint64
@@ -23,57 +41,137 @@ type Key struct {
 // ErrInvalidUUID is returned when parsing an invalid UUID string.
 var ErrInvalidUUID = errors.New("invalid UUID format")
 
-// Parse parses a UUID string in the format xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+// ErrNonRFCVariant is returned when an input UUID does not carry the RFC 4122
+// variant and encoding it would silently rewrite its variant bits.
+var ErrNonRFCVariant = errors.New("UUID variant is not RFC 4122")
+
+// ErrNotV7 is returned when an input that must be a UUIDv7 has another
+// version.
+var ErrNotV7 = errors.New("UUID is not version 7")
+
+// ErrInvalidLength is returned by EncodeBytes and DecodeBytes when the slice
+// is not exactly 16 bytes long.
+var ErrInvalidLength = errors.New("UUID must be 16 bytes")
+
+// Parse parses a UUID string in any of the forms commonly produced by other
+// ecosystems, the same set google/uuid accepts:
+//
+//   - canonical: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+//   - braced: {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
+//   - URN: urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+//   - hyphenless: 32 hex digits
+//
+// Hex digits and the URN prefix may be upper or lower case. On failure the
+// error is a *ParseError locating the problem in s; it matches ErrInvalidUUID
+// with errors.Is. Use ParseStrict to accept only canonical lowercase.
 func Parse(s string) (UUID, error) {
-	var u UUID
-	if len(s) != 36 {
-		return u, ErrInvalidUUID
+	return parse(s)
+}
+
+// ParseBytes is like Parse but takes a byte slice, as handed over by HTTP
+// routers and database drivers, without converting it to a string. It has
+// the same validation semantics and does not allocate unless it fails.
+func ParseBytes(b []byte) (UUID, error) {
+	return parse(b)
+}
+
+// parse implements Parse and ParseBytes.
+func parse[T string | []byte](s T) (UUID, error) {
+	switch len(s) {
+	case 36:
+		return parseCanonical(s, 0)
+	case 38:
+		if s[0] != '{' {
+			return UUID{}, &ParseError{Input: string(s), Offset: 0, Reason: ErrBadDelimiter}
+		}
+		if s[37] != '}' {
+			return UUID{}, &ParseError{Input: string(s), Offset: 37, Reason: ErrBadDelimiter}
+		}
+		return parseCanonical(s, 1)
+	case 45:
+		if i := urnPrefixMismatch(s); i >= 0 {
+			return UUID{}, &ParseError{Input: string(s), Offset: i, Reason: ErrBadDelimiter}
+		}
+		return parseCanonical(s, 9)
+	case 32:
+		return parseHexDigits(s, 0, &hyphenlessOffsets)
 	}
+	return UUID{}, &ParseError{Input: string(s), Offset: len(s), Reason: ErrWrongLength}
+}
 
-	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
-		return u, ErrInvalidUUID
+// canonicalOffsets and hyphenlessOffsets hold the offset of the first hex
+// digit of each byte in the canonical and hyphenless forms.
+var (
+	canonicalOffsets  = [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34}
+	hyphenlessOffsets = [16]int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30}
+)
+
+// hasURNPrefix reports whether s starts with urnPrefix, ignoring ASCII case.
+func hasURNPrefix[T string | []byte](s T) bool {
+	return len(s) >= len(urnPrefix) && urnPrefixMismatch(s) < 0
+}
+
+// urnPrefixMismatch returns the offset of the first byte of s that differs
+// from urnPrefix, ignoring the case of letters, or -1 if s starts with it. s
+// must be at least as long as urnPrefix.
+func urnPrefixMismatch[T string | []byte](s T) int {
+	for i := range len(urnPrefix) {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != urnPrefix[i] {
+			return i
+		}
 	}
+	return -1
+}
 
-	// Decode hex nibbles directly, skipping validated hyphen positions.
-	j := 0
-	for i := 0; i < 36; {
-		if s[i] == '-' {
-			i++
-			continue
+// parseCanonical decodes the 36-byte canonical form starting at offset off
+// in s. Errors report offsets within s.
+func parseCanonical[T string | []byte](s T, off int) (UUID, error) {
+	for _, i := range [4]int{8, 13, 18, 23} {
+		if s[off+i] != '-' {
+			return UUID{}, &ParseError{Input: string(s), Offset: off + i, Reason: ErrBadHyphen}
 		}
+	}
+	return parseHexDigits(s, off, &canonicalOffsets)
+}
+
+// parseHexDigits decodes the hex digit pair for each byte at off plus the
+// corresponding entry of offsets.
+func parseHexDigits[T string | []byte](s T, off int, offsets *[16]int) (UUID, error) {
+	var u UUID
+	for j, i := range offsets {
+		i += off
 		hi, ok := hexNibble(s[i])
 		if !ok {
-			return u, ErrInvalidUUID
+			return UUID{}, &ParseError{Input: string(s), Offset: i, Reason: ErrBadHexDigit}
 		}
 		lo, ok := hexNibble(s[i+1])
 		if !ok {
-			return u, ErrInvalidUUID
+			return UUID{}, &ParseError{Input: string(s), Offset: i + 1, Reason: ErrBadHexDigit}
 		}
 		u[j] = (hi << 4) | lo
-		j++
-		i += 2
 	}
 	return u, nil
 }
 
+// MustParse is like Parse but panics if s cannot be parsed. It is intended for
+// tests, fixtures, and package-level variables holding known-good literals.
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic("uuid47: MustParse(" + strconv.Quote(s) + "): " + err.Error())
+	}
+	return u
+}
+
 // String returns the canonical string representation of a UUID.
 func (u UUID) String() string {
-	const hexdigits = "0123456789abcdef"
 	var buf [36]byte
-
-	// Format as 8-4-4-4-12
-	j := 0
-	for i := range 16 {
-		if i == 4 || i == 6 || i == 8 || i == 10 {
-			buf[j] = '-'
-			j++
-		}
-		buf[j] = hexdigits[(u[i]>>4)&0xF]
-		buf[j+1] = hexdigits[u[i]&0xF]
-		j += 2
-	}
-
-	return string(buf[:])
+	n := formatGroups(buf[:], u, '-', hexLower)
+	return string(buf[:n])
 }
 
 // NewRandomKey generates a cryptographically secure random key.
@@ -88,7 +186,26 @@ func NewRandomKey() (Key, error) {
 	}, nil
 }
 
+// MustNewRandomKey is like NewRandomKey but panics if the system random source
+// fails, which on supported platforms does not happen in practice.
+func MustNewRandomKey() Key {
+	key, err := NewRandomKey()
+	if err != nil {
+		panic("uuid47: MustNewRandomKey: " + err.Error())
+	}
+	return key
+}
+
 // Encode converts a UUIDv7 to a UUIDv4-looking facade.
+//
+// Encode is a pure function: the same uuid and key always yield the same
+// facade, with no hidden state, so it is safe to call concurrently and to rely
+// on for idempotent API responses.
+//
+// The facade always carries the RFC 4122 variant. If the input has a different
+// variant (for example Microsoft, 110x), the overwritten variant bit is lost and
+// Decode will return the RFC variant instead. Use EncodeCheckVariant to reject
+// such inputs rather than changing them silently.
 func Encode(uuid UUID, key Key) UUID {
 	// 1) mask = SipHash24(key, v7.random74bits) -> take low 48 bits
 	sipMsg := buildSipInputFromV7(uuid)
@@ -106,6 +223,57 @@ func Encode(uuid UUID, key Key) UUID {
 	return out
 }
 
+// EncodeStable is identical to Encode. It exists to signal at call sites that
+// the caller depends on the facade being stable across retries, which Encode
+// guarantees.
+func EncodeStable(uuid UUID, key Key) UUID {
+	return Encode(uuid, key)
+}
+
+// EncodeCheckVariant is like Encode but returns ErrNonRFCVariant if the input
+// does not carry the RFC 4122 variant. The facade has no spare bits in which
+// to preserve a foreign variant without giving up reversibility of the random
+// bits, so refusing the input is the only lossless option.
+func EncodeCheckVariant(uuid UUID, key Key) (UUID, error) {
+	if err := checkVariant(uuid); err != nil {
+		return UUID{}, err
+	}
+	return Encode(uuid, key), nil
+}
+
+// EncodeStrict is like Encode but rejects anything that is not an RFC 4122
+// UUIDv7. Encode masks whatever is in the first 48 bits, so a v4 or v1 passed
+// by mistake yields a facade that decodes to a meaningless v7; EncodeStrict
+// returns ErrNonRFCVariant for a foreign variant and ErrNotV7 for any other
+// version instead.
+func EncodeStrict(uuid UUID, key Key) (UUID, error) {
+	if err := checkV7(uuid); err != nil {
+		return UUID{}, err
+	}
+	return Encode(uuid, key), nil
+}
+
+// checkVariant returns ErrNonRFCVariant if u does not carry the RFC 4122
+// variant.
+func checkVariant(u UUID) error {
+	if !isRFCVariant(u) {
+		return ErrNonRFCVariant
+	}
+	return nil
+}
+
+// checkV7 returns ErrNonRFCVariant if u does not carry the RFC 4122 variant,
+// and otherwise ErrNotV7 if it is not version 7.
+func checkV7(u UUID) error {
+	if err := checkVariant(u); err != nil {
+		return err
+	}
+	if !isV7(u) {
+		return ErrNotV7
+	}
+	return nil
+}
+
 // Decode reverses the facade, recovering the original UUIDv7.
 func Decode(uuid UUID, key Key) UUID {
 	// 1) rebuild same Sip input from facade (identical bytes)
@@ -124,8 +292,109 @@ func Decode(uuid UUID, key Key) UUID {
 	return out
 }
 
+// DecodeFast is Decode for trusted input that is known to be a well-formed
+// facade (version 4, RFC variant). It performs no safety checks: it flips the
+// version nibble from 4 to 7 in place and leaves the variant bits untouched
+// rather than re-stamping them. For such input the result is identical to
+// Decode; for anything else it is undefined. SipHash dominates the cost of
+// both, so the saving is a few percent at most; see BenchmarkDecodeFast.
+func DecodeFast(uuid UUID, key Key) UUID {
+	sipMsg := buildSipInputFromV7(uuid)
+	mask48 := siphash.Hash(key.K0, key.K1, sipMsg[:]) & 0x0000FFFFFFFFFFFF
+	wr48be(uuid[:6], rd48be(uuid[:6])^mask48)
+	uuid[6] ^= 0x30 // 0100 (v4) -> 0111 (v7)
+	return uuid
+}
+
+// ReEncode converts a facade produced under oldKey into the facade for the same
+// UUIDv7 under newKey, for key rotation. The intermediate v7 is never exposed.
+func ReEncode(facade UUID, oldKey, newKey Key) UUID {
+	return Encode(Decode(facade, oldKey), newKey)
+}
+
+// EncodeString parses s as Parse does, encodes it under key, and returns the
+// facade in canonical form, for callers that hold IDs as strings from JSON or
+// database drivers. Parse errors are returned unchanged. The only allocation
+// is the returned string.
+func EncodeString(s string, key Key) (string, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Encode(u, key).String(), nil
+}
+
+// DecodeString is the inverse of EncodeString: it parses a facade, decodes it
+// under key, and returns the v7 in canonical form.
+func DecodeString(s string, key Key) (string, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Decode(u, key).String(), nil
+}
+
+// EncodeBytes encodes the UUIDv7 held in b, which must be exactly 16 bytes,
+// in place under key. It is meant for hot paths that already hold raw UUIDs,
+// such as pgx binary values or protobuf bytes fields, and neither copies nor
+// allocates. A slice of any other length returns ErrInvalidLength and is left
+// unchanged.
+func EncodeBytes(b []byte, key Key) error {
+	if len(b) != 16 {
+		return ErrInvalidLength
+	}
+	u := (*UUID)(b)
+	wr48be(u[:6], rd48be(u[:6])^mask48(*u, key))
+	setVersion(u, 4)
+	setVariantRFC4122(u)
+	return nil
+}
+
+// DecodeBytes decodes the facade held in b, which must be exactly 16 bytes,
+// in place under key. It is the inverse of EncodeBytes.
+func DecodeBytes(b []byte, key Key) error {
+	if len(b) != 16 {
+		return ErrInvalidLength
+	}
+	u := (*UUID)(b)
+	wr48be(u[:6], rd48be(u[:6])^mask48(*u, key))
+	setVersion(u, 7)
+	setVariantRFC4122(u)
+	return nil
+}
+
 // Internal helper functions
 
+// mask48 returns the low 48 bits of SipHash-2-4 under key over the random
+// bits of u, the value XORed into the timestamp by Encode and Decode.
+func mask48(u UUID, key Key) uint64 {
+	sipMsg := buildSipInputFromV7(u)
+	return siphash.Hash(key.K0, key.K1, sipMsg[:]) & 0x0000FFFFFFFFFFFF
+}
+
+// Hex digit tables used by the formatting functions.
+const (
+	hexLower = "0123456789abcdef"
+	hexUpper = "0123456789ABCDEF"
+)
+
+// formatGroups writes u into dst as 8-4-4-4-12 hex groups using digits,
+// joined by sep. A zero sep omits separators. It returns the number of bytes
+// written; dst must hold at least 36 bytes.
+func formatGroups(dst []byte, u UUID, sep byte, digits string) int {
+	j := 0
+	for i := range 16 {
+		if sep != 0 && (i == 4 || i == 6 || i == 8 || i == 10) {
+			dst[j] = sep
+			j++
+		}
+		dst[j] = digits[u[i]>>4]
+		dst[j+1] = digits[u[i]&0xF]
+		j += 2
+	}
+	return j
+}
+
 // hexNibble converts an ASCII hex character to its 4-bit value.
 func hexNibble(c byte) (byte, bool) {
 	switch {
@@ -177,6 +446,16 @@ func setVersion(u *UUID, ver byte) {
 	u[6] = (u[6] & 0x0F) | ((ver & 0x0F) << 4)
 }
 
+// isV7 reports whether the UUID is version 7 with the RFC 4122 variant.
+func isV7(u UUID) bool {
+	return u[6]>>4 == 7 && isRFCVariant(u)
+}
+
+// isRFCVariant reports whether the UUID carries the RFC 4122 variant (10xxxxxx).
+func isRFCVariant(u UUID) bool {
+	return u[8]&0xC0 == 0x80
+}
+
 // setVariantRFC4122 sets the variant bits to RFC 4122 (10xxxxxx).
 func setVariantRFC4122(u *UUID) {
 	u[8] = (u[8] & 0x3F) | 0x80
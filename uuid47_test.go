@@ -1,6 +1,8 @@
 package uuid47
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/dchest/siphash"
@@ -8,37 +10,13 @@ import (
 
 // Test helper functions
 
-// version returns the version number of the UUID.
-func version(u UUID) int {
-	return int((u[6] >> 4) & 0x0F)
-}
-
-// craftV7 creates a UUIDv7 with the specified timestamp and random bits.
-// This is for testing to match the C implementation's craft_v7 function.
-func craftV7(tsMs48 uint64, randA12 uint16, randB62 uint64) UUID {
-	var u UUID
-
-	// Write 48-bit timestamp
-	wr48be(u[:6], tsMs48&0x0000FFFFFFFFFFFF)
-
-	// Set version to 7
-	setVersion(&u, 7)
-
-	// Set rand_a (12 bits)
-	u[6] = (u[6] & 0xF0) | byte((randA12>>8)&0x0F)
-	u[7] = byte(randA12 & 0xFF)
-
-	// Set variant
-	setVariantRFC4122(&u)
-
-	// Set rand_b (62 bits across bytes 8-15)
-	// First 6 bits go in byte 8 (lower 6 bits)
-	u[8] = (u[8] & 0xC0) | byte((randB62>>56)&0x3F)
-	// Remaining 56 bits go in bytes 9-15
-	for i := range 7 {
-		u[9+i] = byte(randB62 >> (48 - i*8))
+// mustParse parses s or fails the test.
+func mustParse(t testing.TB, s string) UUID {
+	t.Helper()
+	u, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", s, err)
 	}
-
 	return u
 }
 
@@ -69,6 +47,26 @@ func TestNewRandomKey(t *testing.T) {
 	}
 }
 
+func TestMustParse(t *testing.T) {
+	const s = "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	if got := MustParse(s); got != mustParse(t, s) {
+		t.Errorf("MustParse(%q) = %s", s, got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustParse did not panic on invalid input")
+		}
+	}()
+	MustParse("not-a-uuid")
+}
+
+func TestMustNewRandomKey(t *testing.T) {
+	if MustNewRandomKey() == MustNewRandomKey() {
+		t.Error("two random keys should not be equal")
+	}
+}
+
 func TestSipHashVectors(t *testing.T) {
 	// From test_siphash_switch_and_vectors_subset in tests.c
 	k0 := uint64(0x0706050403020100)
@@ -131,8 +129,8 @@ func TestUUIDParseFormatRoundtrip(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	if version(u) != 7 {
-		t.Errorf("Version mismatch: got %d, want 7", version(u))
+	if u.Version() != 7 {
+		t.Errorf("Version mismatch: got %d, want 7", u.Version())
 	}
 
 	out := u.String()
@@ -157,13 +155,20 @@ func TestParseEdgeCases(t *testing.T) {
 		{"empty string", "", true},
 		{"too short", "018f2d9f-9a2a-7def-8c3f", true},
 		{"too long", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f0", true},
-		{"no hyphens", "018f2d9f9a2a7def8c3f7b1a2c4d5e6f", true},
+		{"no hyphens", "018f2d9f9a2a7def8c3f7b1a2c4d5e6f", false},
+		{"braced", "{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}", false},
+		{"URN", "urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", false},
+		{"uppercase URN", "URN:UUID:018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F", false},
+		{"braced hyphenless", "{018f2d9f9a2a7def8c3f7b1a2c4d5e6f}", true},
+		{"URN hyphenless", "urn:uuid:018f2d9f9a2a7def8c3f7b1a2c4d5e6f", true},
+		{"braced URN", "{urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}", true},
 		{"missing first hyphen", "018f2d9f9a2a-7def-8c3f-7b1a2c4d5e6f", true},
 		{"missing second hyphen", "018f2d9f-9a2a7def-8c3f-7b1a2c4d5e6f", true},
 		{"missing third hyphen", "018f2d9f-9a2a-7def8c3f-7b1a2c4d5e6f", true},
 		{"missing fourth hyphen", "018f2d9f-9a2a-7def-8c3f7b1a2c4d5e6f", true},
 		{"hyphens in wrong positions", "018f2d-9f9a2a-7def-8c3f-7b1a2c4d5e6f", true},
 		{"invalid hex characters", "zzzzzzzz-zzzz-zzzz-zzzz-zzzzzzzzzzzz", true},
+		{"hyphen inside last group", "018f2d9f-9a2a-7def-8c3f-7b1a2c-d5e6f", true},
 		{"uppercase hex", "018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F", false},
 		{"mixed case hex", "018f2D9F-9a2A-7dEf-8C3f-7b1A2c4D5e6F", false},
 	}
@@ -192,12 +197,59 @@ func TestParseEdgeCases(t *testing.T) {
 	}
 }
 
+func TestParseForms(t *testing.T) {
+	want := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	for _, s := range []string{
+		"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F",
+		"{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}",
+		"urn:uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"Urn:Uuid:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"018f2d9f9a2a7def8c3f7b1a2c4d5e6f",
+	} {
+		got, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Parse(%q) = %s, want %s", s, got, want)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for _, s := range []string{
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}",
+		"URN:UUID:018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"018f2d9f9a2a7def8c3f7b1a2c4d5e6f",
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6",
+		"018f2d9f-9a2a-7def-8c3f_7b1a2c4d5e6f",
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g",
+		"",
+	} {
+		want, wantErr := Parse(s)
+		got, err := ParseBytes([]byte(s))
+		if got != want {
+			t.Errorf("ParseBytes(%q) = %s, want %s", s, got, want)
+		}
+		if (err == nil) != (wantErr == nil) || (err != nil && err.Error() != wantErr.Error()) {
+			t.Errorf("ParseBytes(%q) error = %v, want %v", s, err, wantErr)
+		}
+	}
+
+	b := []byte("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if n := testing.AllocsPerRun(100, func() { _, _ = ParseBytes(b) }); n != 0 {
+		t.Errorf("ParseBytes allocated %v times, want 0", n)
+	}
+}
+
 func TestVersionVariant(t *testing.T) {
 	// Test from test_version_variant in tests.c
 	var u UUID
 	setVersion(&u, 7)
-	if version(u) != 7 {
-		t.Errorf("SetVersion failed: got %d, want 7", version(u))
+	if u.Version() != 7 {
+		t.Errorf("SetVersion failed: got %d, want 7", u.Version())
 	}
 
 	setVariantRFC4122(&u)
@@ -216,14 +268,14 @@ func TestEncodeDecodeRoundtrip(t *testing.T) {
 		ra := uint16((0x0AAA ^ uint32(i)*7) & 0x0FFF) //nolint:gosec // G115: Safe conversion in test with i < 16
 		rb := (uint64(0x0123456789ABCDEF) ^ (0x1111111111111111 * i)) & ((1 << 62) - 1)
 
-		u7 := craftV7(ts, ra, rb)
+		u7 := NewV7FromParts(ts, ra, rb)
 
 		// Encode to v4 facade
 		facade := Encode(u7, key)
 
 		// Check version is 4
-		if version(facade) != 4 {
-			t.Errorf("Facade version should be 4, got %d", version(facade))
+		if facade.Version() != 4 {
+			t.Errorf("Facade version should be 4, got %d", facade.Version())
 		}
 
 		// Check variant bits
@@ -249,6 +301,194 @@ func TestEncodeDecodeRoundtrip(t *testing.T) {
 	}
 }
 
+func TestEncodeNonRFCVariant(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	// Same v7 payload, but with the Microsoft variant (110xxxxx) in byte 8.
+	ms, err := Parse("018f2d9f-9a2a-7def-cc3f-7b1a2c4d5e6f")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Encode rewrites the variant, so the roundtrip cannot restore it.
+	back := Decode(Encode(ms, key), key)
+	if back == ms {
+		t.Error("Encode/Decode unexpectedly preserved a Microsoft variant")
+	}
+	if (back[8] & 0xC0) != 0x80 {
+		t.Errorf("Decoded variant bits incorrect: got %02x", back[8])
+	}
+
+	// EncodeCheckVariant refuses instead.
+	if _, err := EncodeCheckVariant(ms, key); !errors.Is(err, ErrNonRFCVariant) {
+		t.Errorf("EncodeCheckVariant error = %v, want ErrNonRFCVariant", err)
+	}
+
+	rfc, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	facade, err := EncodeCheckVariant(rfc, key)
+	if err != nil {
+		t.Fatalf("EncodeCheckVariant failed on RFC input: %v", err)
+	}
+	if facade != Encode(rfc, key) {
+		t.Error("EncodeCheckVariant does not match Encode for RFC input")
+	}
+}
+
+func TestEncodeStrict(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"v7", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", nil},
+		{"v4", "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f", ErrNotV7},
+		{"v1", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrNotV7},
+		{"nil", "00000000-0000-0000-0000-000000000000", ErrNonRFCVariant},
+		{"v7 Microsoft variant", "018f2d9f-9a2a-7def-cc3f-7b1a2c4d5e6f", ErrNonRFCVariant},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u := mustParse(t, tc.input)
+			got, err := EncodeStrict(u, key)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("EncodeStrict error = %v, want %v", err, tc.wantErr)
+			}
+			if err == nil && got != Encode(u, key) {
+				t.Errorf("EncodeStrict = %s, want %s", got, Encode(u, key))
+			}
+			if err != nil && got != (UUID{}) {
+				t.Errorf("EncodeStrict returned %s alongside an error", got)
+			}
+		})
+	}
+}
+
+func TestEncodeString(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	facade := "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f"
+
+	got, err := EncodeString(v7, key)
+	if err != nil || got != facade {
+		t.Errorf("EncodeString = %q, %v; want %q", got, err, facade)
+	}
+	got, err = DecodeString(facade, key)
+	if err != nil || got != v7 {
+		t.Errorf("DecodeString = %q, %v; want %q", got, err, v7)
+	}
+
+	if _, err := EncodeString("not-a-uuid", key); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("EncodeString error = %v, want ErrInvalidUUID", err)
+	}
+	if _, err := DecodeString(facade[:35], key); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("DecodeString error = %v, want ErrInvalidUUID", err)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _, _ = EncodeString(v7, key) }); n != 1 {
+		t.Errorf("EncodeString allocates %v times, want 1", n)
+	}
+}
+
+func TestEncodeBytes(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	// A UUID in the middle of a larger buffer, as in a wire message.
+	buf := make([]byte, 20)
+	copy(buf[2:18], v7[:])
+	b := buf[2:18]
+
+	if err := EncodeBytes(b, key); err != nil {
+		t.Fatalf("EncodeBytes failed: %v", err)
+	}
+	if UUID(b) != Encode(v7, key) {
+		t.Errorf("EncodeBytes = %x, want %s", b, Encode(v7, key))
+	}
+	if err := DecodeBytes(b, key); err != nil {
+		t.Fatalf("DecodeBytes failed: %v", err)
+	}
+	if UUID(b) != v7 {
+		t.Errorf("DecodeBytes = %x, want %s", b, v7)
+	}
+	if buf[0] != 0 || buf[1] != 0 || buf[18] != 0 || buf[19] != 0 {
+		t.Error("bytes outside the UUID were modified")
+	}
+
+	for _, n := range []int{0, 15, 17} {
+		short := make([]byte, n)
+		if err := EncodeBytes(short, key); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("EncodeBytes(len %d) error = %v, want ErrInvalidLength", n, err)
+		}
+		if err := DecodeBytes(short, key); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("DecodeBytes(len %d) error = %v, want ErrInvalidLength", n, err)
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = EncodeBytes(b, key) }); n != 0 {
+		t.Errorf("EncodeBytes allocates %v times", n)
+	}
+}
+
+func TestEncodeDeterministic(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	want := Encode(u7, key)
+
+	for range 100 {
+		if got := Encode(u7, key); got != want {
+			t.Fatalf("Encode not deterministic: got %s, want %s", got, want)
+		}
+		if got := EncodeStable(u7, key); got != want {
+			t.Fatalf("EncodeStable differs from Encode: got %s, want %s", got, want)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Go(func() {
+			for range 1000 {
+				if got := Encode(u7, key); got != want {
+					t.Errorf("concurrent Encode not deterministic: got %s, want %s", got, want)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestDecodeFast(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	for i := range uint64(16) {
+		ts := 0x100000*i + 123
+		ra := uint16((0x0AAA ^ uint32(i)*7) & 0x0FFF) //nolint:gosec // G115: Safe conversion in test with i < 16
+		rb := (uint64(0x0123456789ABCDEF) ^ (0x1111111111111111 * i)) & ((1 << 62) - 1)
+		facade := Encode(NewV7FromParts(ts, ra, rb), key)
+
+		if got, want := DecodeFast(facade, key), Decode(facade, key); got != want {
+			t.Errorf("iteration %d: DecodeFast = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestReEncode(t *testing.T) {
+	oldKey := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	newKey := Key{K0: 0x1111111111111111, K1: 0x2222222222222222}
+	u7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	rotated := ReEncode(Encode(u7, oldKey), oldKey, newKey)
+	if rotated != Encode(u7, newKey) {
+		t.Errorf("ReEncode = %s, want %s", rotated, Encode(u7, newKey))
+	}
+	if Decode(rotated, newKey) != u7 {
+		t.Error("rotated facade does not decode under the new key")
+	}
+}
+
 // TestExactCCompatibility verifies our implementation matches C exactly
 func TestExactCCompatibility(t *testing.T) {
 	// These test vectors were generated from the C implementation
@@ -314,13 +554,13 @@ func TestExactCCompatibility(t *testing.T) {
 	}
 }
 
-func TestCraftV7(t *testing.T) {
-	// Test the CraftV7 function matches C's craft_v7
+func TestNewV7FromParts(t *testing.T) {
+	// NewV7FromParts must match C's craft_v7
 	tsMs48 := uint64(0x0123456789AB)
 	randA12 := uint16(0x0ABC)
 	randB62 := uint64(0x0FEDCBA987654321) & ((1 << 62) - 1)
 
-	u := craftV7(tsMs48, randA12, randB62)
+	u := NewV7FromParts(tsMs48, randA12, randB62)
 
 	// Check timestamp
 	gotTs := rd48be(u[:6])
@@ -330,8 +570,8 @@ func TestCraftV7(t *testing.T) {
 	}
 
 	// Check version
-	if version(u) != 7 {
-		t.Errorf("Version should be 7, got %d", version(u))
+	if u.Version() != 7 {
+		t.Errorf("Version should be 7, got %d", u.Version())
 	}
 
 	// Check rand_a (12 bits)
@@ -400,6 +640,16 @@ func BenchmarkDecode(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodeFast(b *testing.B) {
+	u7, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := Encode(u7, key)
+
+	for b.Loop() {
+		_ = DecodeFast(facade, key)
+	}
+}
+
 func BenchmarkRoundtrip(b *testing.B) {
 	u7, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
 	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
@@ -410,6 +660,14 @@ func BenchmarkRoundtrip(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodeString(b *testing.B) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	for b.Loop() {
+		_, _ = EncodeString("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", key)
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	s := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
 
@@ -418,6 +676,14 @@ func BenchmarkParse(b *testing.B) {
 	}
 }
 
+func BenchmarkParseBytes(b *testing.B) {
+	s := []byte("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	for b.Loop() {
+		_, _ = ParseBytes(s)
+	}
+}
+
 func BenchmarkString(b *testing.B) {
 	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
 
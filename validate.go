@@ -0,0 +1,32 @@
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWrongVersion is returned by Validate when a UUID has a version other
+// than the one expected.
+var ErrWrongVersion = errors.New("unexpected UUID version")
+
+// ValidateString reports whether s is a well-formed UUID string, returning
+// the *ParseError Parse would return, or nil. It is a pre-check for request
+// validation layers and, like ParseBytes, does not allocate unless it fails.
+func ValidateString(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+// Validate checks that u carries the RFC 4122 variant, returning
+// ErrNonRFCVariant otherwise, and that its version is expectVersion,
+// returning an error wrapping ErrWrongVersion otherwise. An expectVersion of
+// 0 accepts any version.
+func (u UUID) Validate(expectVersion int) error {
+	if !isRFCVariant(u) {
+		return ErrNonRFCVariant
+	}
+	if v := int(u.Version()); expectVersion != 0 && v != expectVersion {
+		return fmt.Errorf("%w: got %d, want %d", ErrWrongVersion, v, expectVersion)
+	}
+	return nil
+}
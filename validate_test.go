@@ -0,0 +1,60 @@
+package uuid47
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateString(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr error
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", nil},
+		{"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F", nil},
+		{"018f2d9f-9a2a-7def-8c3f", ErrWrongLength},
+		{"018f2d9f-9a2a-7def-8c3f+7b1a2c4d5e6f", ErrBadHyphen},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6x", ErrBadHexDigit},
+	}
+
+	for _, tc := range tests {
+		err := ValidateString(tc.input)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("ValidateString(%q) = %v, want %v", tc.input, err, tc.wantErr)
+		}
+	}
+
+	s := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	if n := testing.AllocsPerRun(100, func() { _ = ValidateString(s) }); n != 0 {
+		t.Errorf("ValidateString allocates %v times", n)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	v7 := mustParse(t, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	v4 := mustParse(t, "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name    string
+		u       UUID
+		version int
+		wantErr error
+	}{
+		{"v7 as v7", v7, 7, nil},
+		{"v4 as v4", v4, 4, nil},
+		{"v7 as any", v7, 0, nil},
+		{"v4 as v7", v4, 7, ErrWrongVersion},
+		{"v7 as v4", v7, 4, ErrWrongVersion},
+		{"nil", Nil, 0, ErrNonRFCVariant},
+		{"Microsoft variant", mustParse(t, "018f2d9f-9a2a-7def-cc3f-7b1a2c4d5e6f"), 7, ErrNonRFCVariant},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.u.Validate(tc.version)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Validate(%d) = %v, want %v", tc.version, err, tc.wantErr)
+			}
+		})
+	}
+}